@@ -0,0 +1,113 @@
+package publicclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/sunsetlover36/mjolnir/internal"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type Batch struct {
+	client *internal.RpcClient
+	batch  *internal.Batch
+}
+
+func (c *PublicClient) NewBatch() *Batch {
+	return &Batch{client: c.client, batch: c.client.NewBatch()}
+}
+
+func (b *Batch) Send() error {
+	return b.SendContext(context.Background())
+}
+func (b *Batch) SendContext(ctx context.Context) error {
+	return b.batch.Send(ctx)
+}
+
+func (b *Batch) GetBlock(params types.GetBlockParams) *types.BatchResult[*types.Block] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.Block, error) {
+		return b.client.GetBlock(ctx, params)
+	})
+}
+
+func (b *Batch) GetBlockNumber() *types.BatchResult[uint64] {
+	return internal.AddToBatch(b.batch, b.client.GetBlockNumber)
+}
+
+func (b *Batch) GetBlockTransactionCount(params types.GetBlockTransactionCountParams) *types.BatchResult[uint64] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (uint64, error) {
+		return b.client.GetBlockTransactionCount(ctx, params)
+	})
+}
+
+func (b *Batch) GetBalance(address string) *types.BatchResult[*big.Int] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*big.Int, error) {
+		return b.client.GetBalance(ctx, address)
+	})
+}
+
+func (b *Batch) GetTransactionCount(address string) *types.BatchResult[uint64] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (uint64, error) {
+		return b.client.GetTransactionCount(ctx, address)
+	})
+}
+
+func (b *Batch) GetTransaction(hash string) *types.BatchResult[*types.Transaction] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.Transaction, error) {
+		return b.client.GetTransaction(ctx, hash)
+	})
+}
+
+func (b *Batch) GetTransactionReceipt(hash string) *types.BatchResult[*types.Receipt] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.Receipt, error) {
+		return b.client.GetTransactionReceipt(ctx, hash)
+	})
+}
+
+func (b *Batch) GetGasPrice() *types.BatchResult[*big.Int] {
+	return internal.AddToBatch(b.batch, b.client.GetGasPrice)
+}
+func (b *Batch) GetMaxPriorityFeePerGas() *types.BatchResult[*big.Int] {
+	return internal.AddToBatch(b.batch, b.client.GetMaxPriorityFeePerGas)
+}
+func (b *Batch) GetFeeHistory(params types.GetFeeHistoryParams) *types.BatchResult[*types.FeeHistory] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.FeeHistory, error) {
+		return b.client.GetFeeHistory(ctx, params)
+	})
+}
+func (b *Batch) EstimateFeesPerGas(params types.EstimateFeesPerGasParams) *types.BatchResult[*types.FeeEstimate] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.FeeEstimate, error) {
+		return b.client.EstimateFeesPerGas(ctx, params)
+	})
+}
+func (b *Batch) GetBlobBaseFee() *types.BatchResult[*big.Int] {
+	return internal.AddToBatch(b.batch, b.client.GetBlobBaseFee)
+}
+func (b *Batch) EstimateGas(params types.CallParams) *types.BatchResult[*big.Int] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*big.Int, error) {
+		return b.client.EstimateGas(ctx, params)
+	})
+}
+
+func (b *Batch) CreateAccessList(params types.CallParams) *types.BatchResult[*types.AccessListResult] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.AccessListResult, error) {
+		return b.client.CreateAccessList(ctx, params)
+	})
+}
+
+func (b *Batch) GetLogs(params types.GetLogsParams) *types.BatchResult[[]types.Log] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) ([]types.Log, error) {
+		return b.client.GetLogs(ctx, params)
+	})
+}
+
+func (b *Batch) ReadContract(params types.ReadContractParams) *types.BatchResult[[]byte] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) ([]byte, error) {
+		return b.client.ReadContract(ctx, params)
+	})
+}
+func (b *Batch) SimulateContract(params types.ContractInteractionParams) *types.BatchResult[*types.SimulateTxResult] {
+	return internal.AddToBatch(b.batch, func(ctx context.Context) (*types.SimulateTxResult, error) {
+		return b.client.SimulateContract(ctx, params)
+	})
+}
@@ -8,7 +8,15 @@ import (
 func NewPublicClient(params types.NewPublicClientParams) *PublicClient {
 	return &PublicClient{
 		client: internal.NewRpcClient(types.NewRpcClientParams{
-			RpcUrl: params.RpcUrl,
+			RpcUrl:      params.RpcUrl,
+			Transport:   params.Transport,
+			Batch:       params.Batch,
+			Retry:       params.Retry,
+			FeeStrategy: params.FeeStrategy,
 		}),
 	}
 }
+
+func (c *PublicClient) Close() error {
+	return c.client.Close()
+}
@@ -1,6 +1,7 @@
 package publicclient
 
 import (
+	"context"
 	"math/big"
 
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
@@ -8,45 +9,159 @@ import (
 )
 
 func (c *PublicClient) GetBlock(params types.GetBlockParams) (*types.Block, error) {
-	return c.client.GetBlock(params)
+	return c.GetBlockContext(context.Background(), params)
+}
+func (c *PublicClient) GetBlockContext(ctx context.Context, params types.GetBlockParams) (*types.Block, error) {
+	return c.client.GetBlock(ctx, params)
 }
 
 func (c *PublicClient) GetBlockNumber() (uint64, error) {
-	return c.client.GetBlockNumber()
+	return c.GetBlockNumberContext(context.Background())
+}
+func (c *PublicClient) GetBlockNumberContext(ctx context.Context) (uint64, error) {
+	return c.client.GetBlockNumber(ctx)
 }
 
 func (c *PublicClient) GetBlockTransactionCount(params types.GetBlockTransactionCountParams) (uint64, error) {
-	return c.client.GetBlockTransactionCount(params)
+	return c.GetBlockTransactionCountContext(context.Background(), params)
+}
+func (c *PublicClient) GetBlockTransactionCountContext(ctx context.Context, params types.GetBlockTransactionCountParams) (uint64, error) {
+	return c.client.GetBlockTransactionCount(ctx, params)
 }
 
 func (c *PublicClient) GetBalance(address string) (*big.Int, error) {
-	return c.client.GetBalance(address)
+	return c.GetBalanceContext(context.Background(), address)
+}
+func (c *PublicClient) GetBalanceContext(ctx context.Context, address string) (*big.Int, error) {
+	return c.client.GetBalance(ctx, address)
 }
 
 func (c *PublicClient) GetTransactionCount(address string) (uint64, error) {
-	return c.client.GetTransactionCount(address)
+	return c.GetTransactionCountContext(context.Background(), address)
+}
+func (c *PublicClient) GetTransactionCountContext(ctx context.Context, address string) (uint64, error) {
+	return c.client.GetTransactionCount(ctx, address)
+}
+
+func (c *PublicClient) GetTransaction(hash string) (*types.Transaction, error) {
+	return c.GetTransactionContext(context.Background(), hash)
+}
+func (c *PublicClient) GetTransactionContext(ctx context.Context, hash string) (*types.Transaction, error) {
+	return c.client.GetTransaction(ctx, hash)
+}
+
+func (c *PublicClient) GetTransactionReceipt(hash string) (*types.Receipt, error) {
+	return c.GetTransactionReceiptContext(context.Background(), hash)
+}
+func (c *PublicClient) GetTransactionReceiptContext(ctx context.Context, hash string) (*types.Receipt, error) {
+	return c.client.GetTransactionReceipt(ctx, hash)
+}
+
+func (c *PublicClient) WaitForTransactionReceipt(params types.WaitForTransactionReceiptParams) (*types.Receipt, error) {
+	return c.WaitForTransactionReceiptContext(context.Background(), params)
+}
+func (c *PublicClient) WaitForTransactionReceiptContext(ctx context.Context, params types.WaitForTransactionReceiptParams) (*types.Receipt, error) {
+	return c.client.WaitForTransactionReceipt(ctx, params)
 }
 
 func (c *PublicClient) GetGasPrice() (*big.Int, error) {
-	return c.client.GetGasPrice()
+	return c.GetGasPriceContext(context.Background())
+}
+func (c *PublicClient) GetGasPriceContext(ctx context.Context) (*big.Int, error) {
+	return c.client.GetGasPrice(ctx)
+}
+
+func (c *PublicClient) GetFeeHistory(params types.GetFeeHistoryParams) (*types.FeeHistory, error) {
+	return c.GetFeeHistoryContext(context.Background(), params)
+}
+func (c *PublicClient) GetFeeHistoryContext(ctx context.Context, params types.GetFeeHistoryParams) (*types.FeeHistory, error) {
+	return c.client.GetFeeHistory(ctx, params)
+}
+
+func (c *PublicClient) EstimateFeesPerGas(params types.EstimateFeesPerGasParams) (*types.FeeEstimate, error) {
+	return c.EstimateFeesPerGasContext(context.Background(), params)
+}
+func (c *PublicClient) EstimateFeesPerGasContext(ctx context.Context, params types.EstimateFeesPerGasParams) (*types.FeeEstimate, error) {
+	return c.client.EstimateFeesPerGas(ctx, params)
+}
+
+func (c *PublicClient) GetBlobBaseFee() (*big.Int, error) {
+	return c.GetBlobBaseFeeContext(context.Background())
+}
+func (c *PublicClient) GetBlobBaseFeeContext(ctx context.Context) (*big.Int, error) {
+	return c.client.GetBlobBaseFee(ctx)
 }
 func (c *PublicClient) GetMaxPriorityFeePerGas() (*big.Int, error) {
-	return c.client.GetMaxPriorityFeePerGas()
+	return c.GetMaxPriorityFeePerGasContext(context.Background())
+}
+func (c *PublicClient) GetMaxPriorityFeePerGasContext(ctx context.Context) (*big.Int, error) {
+	return c.client.GetMaxPriorityFeePerGas(ctx)
 }
 func (c *PublicClient) EstimateGas(params types.CallParams) (*big.Int, error) {
-	return c.client.EstimateGas(params)
+	return c.EstimateGasContext(context.Background(), params)
+}
+func (c *PublicClient) EstimateGasContext(ctx context.Context, params types.CallParams) (*big.Int, error) {
+	return c.client.EstimateGas(ctx, params)
+}
+
+func (c *PublicClient) CreateAccessList(params types.CallParams) (*types.AccessListResult, error) {
+	return c.CreateAccessListContext(context.Background(), params)
+}
+func (c *PublicClient) CreateAccessListContext(ctx context.Context, params types.CallParams) (*types.AccessListResult, error) {
+	return c.client.CreateAccessList(ctx, params)
 }
 
 func (c *PublicClient) PrepareTxRequest(params types.TxInteractionParams) (*ethTypes.Transaction, error) {
-	return c.client.PrepareTxRequest(params)
+	return c.PrepareTxRequestContext(context.Background(), params)
+}
+func (c *PublicClient) PrepareTxRequestContext(ctx context.Context, params types.TxInteractionParams) (*ethTypes.Transaction, error) {
+	return c.client.PrepareTxRequest(ctx, params)
 }
 func (c *PublicClient) SimulateTx(params types.TxInteractionParams) (*types.SimulateTxResult, error) {
-	return c.client.SimulateTx(params)
+	return c.SimulateTxContext(context.Background(), params)
+}
+func (c *PublicClient) SimulateTxContext(ctx context.Context, params types.TxInteractionParams) (*types.SimulateTxResult, error) {
+	return c.client.SimulateTx(ctx, params)
+}
+
+func (c *PublicClient) GetLogs(params types.GetLogsParams) ([]types.Log, error) {
+	return c.GetLogsContext(context.Background(), params)
+}
+func (c *PublicClient) GetLogsContext(ctx context.Context, params types.GetLogsParams) ([]types.Log, error) {
+	return c.client.GetLogs(ctx, params)
+}
+func (c *PublicClient) GetContractEvents(params types.GetContractEventsParams) ([]types.ContractEvent, error) {
+	return c.GetContractEventsContext(context.Background(), params)
+}
+func (c *PublicClient) GetContractEventsContext(ctx context.Context, params types.GetContractEventsParams) ([]types.ContractEvent, error) {
+	return c.client.GetContractEvents(ctx, params)
 }
 
+func (c *PublicClient) VerifySignature(params types.VerifySignatureParams) (bool, error) {
+	return c.VerifySignatureContext(context.Background(), params)
+}
+func (c *PublicClient) VerifySignatureContext(ctx context.Context, params types.VerifySignatureParams) (bool, error) {
+	return c.client.VerifySignature(ctx, params)
+}
 func (c *PublicClient) ReadContract(params types.ReadContractParams) ([]byte, error) {
-	return c.client.ReadContract(params)
+	return c.ReadContractContext(context.Background(), params)
+}
+func (c *PublicClient) ReadContractContext(ctx context.Context, params types.ReadContractParams) ([]byte, error) {
+	return c.client.ReadContract(ctx, params)
 }
 func (c *PublicClient) SimulateContract(params types.ContractInteractionParams) (*types.SimulateTxResult, error) {
-	return c.client.SimulateContract(params)
+	return c.SimulateContractContext(context.Background(), params)
+}
+func (c *PublicClient) SimulateContractContext(ctx context.Context, params types.ContractInteractionParams) (*types.SimulateTxResult, error) {
+	return c.client.SimulateContract(ctx, params)
+}
+
+func (c *PublicClient) WatchBlockNumber(params types.WatchBlockNumberParams) (unwatch func()) {
+	return c.client.WatchBlockNumber(params)
+}
+func (c *PublicClient) WatchBlocks(params types.WatchBlocksParams) (unwatch func()) {
+	return c.client.WatchBlocks(params)
+}
+func (c *PublicClient) WatchContractEvent(params types.WatchContractEventParams) (unwatch func(), err error) {
+	return c.client.WatchContractEvent(params)
 }
@@ -6,11 +6,48 @@ import (
 )
 
 func NewWalletClient(params types.NewWalletClientParams) *WalletClient {
+	signer := params.Signer
+	if signer == nil && params.Account != nil {
+		signer = internal.NewLocalSigner(params.Account)
+	}
+
+	nonceManager := params.NonceManager
+	if nonceManager == nil {
+		nonceManager = internal.NewNonceManager()
+	}
+
 	return &WalletClient{
 		client: internal.NewRpcClient(types.NewRpcClientParams{
-			Chain:  params.Chain,
-			RpcUrl: params.RpcUrl,
+			Chain:        params.Chain,
+			RpcUrl:       params.RpcUrl,
+			Transport:    params.Transport,
+			Batch:        params.Batch,
+			Retry:        params.Retry,
+			NonceManager: nonceManager,
+			FeeStrategy:  params.FeeStrategy,
 		}),
-		account: params.Account,
+		signer: signer,
+	}
+}
+
+// Address returns the address the client signs for, or an empty string if
+// it has neither an Account nor a Signer.
+func (c *WalletClient) Address() string {
+	if c.signer == nil {
+		return ""
+	}
+	return c.signer.Address()
+}
+
+// requireSigner fails the methods that sign or act for the account when the
+// client was created without an Account or a Signer.
+func requireSigner(signer types.Signer) (types.Signer, error) {
+	if signer == nil {
+		return nil, types.ErrNoSigner
 	}
+	return signer, nil
+}
+
+func (c *WalletClient) Close() error {
+	return c.client.Close()
 }
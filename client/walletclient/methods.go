@@ -1,6 +1,7 @@
 package walletclient
 
 import (
+	"context"
 	"math/big"
 
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
@@ -8,55 +9,251 @@ import (
 )
 
 func (c *WalletClient) GetBlock(params types.GetBlockParams) (*types.Block, error) {
-	return c.client.GetBlock(params)
+	return c.GetBlockContext(context.Background(), params)
+}
+func (c *WalletClient) GetBlockContext(ctx context.Context, params types.GetBlockParams) (*types.Block, error) {
+	return c.client.GetBlock(ctx, params)
 }
 
 func (c *WalletClient) GetBlockNumber() (uint64, error) {
-	return c.client.GetBlockNumber()
+	return c.GetBlockNumberContext(context.Background())
+}
+func (c *WalletClient) GetBlockNumberContext(ctx context.Context) (uint64, error) {
+	return c.client.GetBlockNumber(ctx)
 }
 
 func (c *WalletClient) GetBlockTransactionCount(params types.GetBlockTransactionCountParams) (uint64, error) {
-	return c.client.GetBlockTransactionCount(params)
+	return c.GetBlockTransactionCountContext(context.Background(), params)
+}
+func (c *WalletClient) GetBlockTransactionCountContext(ctx context.Context, params types.GetBlockTransactionCountParams) (uint64, error) {
+	return c.client.GetBlockTransactionCount(ctx, params)
 }
 
 func (c *WalletClient) GetBalance() (*big.Int, error) {
-	return c.client.GetBalance(c.account.Address)
+	return c.GetBalanceContext(context.Background())
+}
+func (c *WalletClient) GetBalanceContext(ctx context.Context) (*big.Int, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.GetBalance(ctx, signer.Address())
 }
 
 func (c *WalletClient) GetTransactionCount() (uint64, error) {
-	return c.client.GetTransactionCount(c.account.Address)
+	return c.GetTransactionCountContext(context.Background())
+}
+func (c *WalletClient) GetTransactionCountContext(ctx context.Context) (uint64, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return 0, err
+	}
+	return c.client.GetTransactionCount(ctx, signer.Address())
+}
+
+func (c *WalletClient) GetTransaction(hash string) (*types.Transaction, error) {
+	return c.GetTransactionContext(context.Background(), hash)
+}
+func (c *WalletClient) GetTransactionContext(ctx context.Context, hash string) (*types.Transaction, error) {
+	return c.client.GetTransaction(ctx, hash)
+}
+
+func (c *WalletClient) GetTransactionReceipt(hash string) (*types.Receipt, error) {
+	return c.GetTransactionReceiptContext(context.Background(), hash)
+}
+func (c *WalletClient) GetTransactionReceiptContext(ctx context.Context, hash string) (*types.Receipt, error) {
+	return c.client.GetTransactionReceipt(ctx, hash)
+}
+
+func (c *WalletClient) WaitForTransactionReceipt(params types.WaitForTransactionReceiptParams) (*types.Receipt, error) {
+	return c.WaitForTransactionReceiptContext(context.Background(), params)
+}
+func (c *WalletClient) WaitForTransactionReceiptContext(ctx context.Context, params types.WaitForTransactionReceiptParams) (*types.Receipt, error) {
+	return c.client.WaitForTransactionReceipt(ctx, params)
 }
 
 func (c *WalletClient) GetGasPrice() (*big.Int, error) {
-	return c.client.GetGasPrice()
+	return c.GetGasPriceContext(context.Background())
+}
+func (c *WalletClient) GetGasPriceContext(ctx context.Context) (*big.Int, error) {
+	return c.client.GetGasPrice(ctx)
+}
+
+func (c *WalletClient) GetFeeHistory(params types.GetFeeHistoryParams) (*types.FeeHistory, error) {
+	return c.GetFeeHistoryContext(context.Background(), params)
+}
+func (c *WalletClient) GetFeeHistoryContext(ctx context.Context, params types.GetFeeHistoryParams) (*types.FeeHistory, error) {
+	return c.client.GetFeeHistory(ctx, params)
+}
+
+func (c *WalletClient) EstimateFeesPerGas(params types.EstimateFeesPerGasParams) (*types.FeeEstimate, error) {
+	return c.EstimateFeesPerGasContext(context.Background(), params)
+}
+func (c *WalletClient) EstimateFeesPerGasContext(ctx context.Context, params types.EstimateFeesPerGasParams) (*types.FeeEstimate, error) {
+	return c.client.EstimateFeesPerGas(ctx, params)
+}
+
+func (c *WalletClient) GetBlobBaseFee() (*big.Int, error) {
+	return c.GetBlobBaseFeeContext(context.Background())
+}
+func (c *WalletClient) GetBlobBaseFeeContext(ctx context.Context) (*big.Int, error) {
+	return c.client.GetBlobBaseFee(ctx)
 }
 func (c *WalletClient) GetMaxPriorityFeePerGas() (*big.Int, error) {
-	return c.client.GetMaxPriorityFeePerGas()
+	return c.GetMaxPriorityFeePerGasContext(context.Background())
+}
+func (c *WalletClient) GetMaxPriorityFeePerGasContext(ctx context.Context) (*big.Int, error) {
+	return c.client.GetMaxPriorityFeePerGas(ctx)
 }
 func (c *WalletClient) EstimateGas(params types.CallParams) (*big.Int, error) {
-	return c.client.EstimateGas(params)
+	return c.EstimateGasContext(context.Background(), params)
+}
+func (c *WalletClient) EstimateGasContext(ctx context.Context, params types.CallParams) (*big.Int, error) {
+	return c.client.EstimateGas(ctx, params)
+}
+
+func (c *WalletClient) CreateAccessList(params types.CallParams) (*types.AccessListResult, error) {
+	return c.CreateAccessListContext(context.Background(), params)
+}
+func (c *WalletClient) CreateAccessListContext(ctx context.Context, params types.CallParams) (*types.AccessListResult, error) {
+	return c.client.CreateAccessList(ctx, params)
 }
 
 func (c *WalletClient) PrepareTxRequest(params types.TxInteractionParams) (*ethTypes.Transaction, error) {
-	return c.client.PrepareTxRequest(params)
+	return c.PrepareTxRequestContext(context.Background(), params)
+}
+func (c *WalletClient) PrepareTxRequestContext(ctx context.Context, params types.TxInteractionParams) (*ethTypes.Transaction, error) {
+	return c.client.PrepareTxRequest(ctx, params)
 }
 func (c *WalletClient) SimulateTx(params types.TxInteractionParams) (*types.SimulateTxResult, error) {
-	params.Account = c.account
-	return c.client.SimulateTx(params)
+	return c.SimulateTxContext(context.Background(), params)
+}
+func (c *WalletClient) SimulateTxContext(ctx context.Context, params types.TxInteractionParams) (*types.SimulateTxResult, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return nil, err
+	}
+	params.Signer = signer
+	return c.client.SimulateTx(ctx, params)
 }
 func (c *WalletClient) SendTx(params *types.TxInteractionParams) (string, error) {
-	params.Account = c.account
-	return c.client.SendTx(*params)
+	return c.SendTxContext(context.Background(), params)
+}
+func (c *WalletClient) SendTxContext(ctx context.Context, params *types.TxInteractionParams) (string, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return "", err
+	}
+	params.Signer = signer
+	return c.client.SendTx(ctx, *params)
+}
+
+func (c *WalletClient) GetLogs(params types.GetLogsParams) ([]types.Log, error) {
+	return c.GetLogsContext(context.Background(), params)
+}
+func (c *WalletClient) GetLogsContext(ctx context.Context, params types.GetLogsParams) ([]types.Log, error) {
+	return c.client.GetLogs(ctx, params)
+}
+func (c *WalletClient) GetContractEvents(params types.GetContractEventsParams) ([]types.ContractEvent, error) {
+	return c.GetContractEventsContext(context.Background(), params)
+}
+func (c *WalletClient) GetContractEventsContext(ctx context.Context, params types.GetContractEventsParams) ([]types.ContractEvent, error) {
+	return c.client.GetContractEvents(ctx, params)
 }
 
+func (c *WalletClient) VerifySignature(params types.VerifySignatureParams) (bool, error) {
+	return c.VerifySignatureContext(context.Background(), params)
+}
+func (c *WalletClient) VerifySignatureContext(ctx context.Context, params types.VerifySignatureParams) (bool, error) {
+	return c.client.VerifySignature(ctx, params)
+}
 func (c *WalletClient) ReadContract(params types.ReadContractParams) ([]byte, error) {
-	return c.client.ReadContract(params)
+	return c.ReadContractContext(context.Background(), params)
+}
+func (c *WalletClient) ReadContractContext(ctx context.Context, params types.ReadContractParams) ([]byte, error) {
+	return c.client.ReadContract(ctx, params)
 }
 func (c *WalletClient) WriteContract(params types.ContractInteractionParams) (string, error) {
-	params.Account = c.account
-	return c.client.WriteContract(params)
+	return c.WriteContractContext(context.Background(), params)
+}
+func (c *WalletClient) WriteContractContext(ctx context.Context, params types.ContractInteractionParams) (string, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return "", err
+	}
+	params.Signer = signer
+	return c.client.WriteContract(ctx, params)
 }
 func (c *WalletClient) SimulateContract(params types.ContractInteractionParams) (*types.SimulateTxResult, error) {
-	params.Account = c.account
-	return c.client.SimulateContract(params)
+	return c.SimulateContractContext(context.Background(), params)
+}
+func (c *WalletClient) SimulateContractContext(ctx context.Context, params types.ContractInteractionParams) (*types.SimulateTxResult, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return nil, err
+	}
+	params.Signer = signer
+	return c.client.SimulateContract(ctx, params)
+}
+func (c *WalletClient) SignAuthorization(params types.SignAuthorizationParams) (*types.SignedAuthorization, error) {
+	return c.SignAuthorizationContext(context.Background(), params)
+}
+func (c *WalletClient) SignAuthorizationContext(ctx context.Context, params types.SignAuthorizationParams) (*types.SignedAuthorization, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.SignAuthorization(ctx, params, signer)
+}
+func (c *WalletClient) PrepareAuthorization(params types.SignAuthorizationParams) (*types.Authorization, error) {
+	return c.PrepareAuthorizationContext(context.Background(), params)
+}
+func (c *WalletClient) PrepareAuthorizationContext(ctx context.Context, params types.SignAuthorizationParams) (*types.Authorization, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.PrepareAuthorization(ctx, params, signer)
+}
+func (c *WalletClient) SpeedUpTransaction(params types.ReplaceTransactionParams) (string, error) {
+	return c.SpeedUpTransactionContext(context.Background(), params)
+}
+func (c *WalletClient) SpeedUpTransactionContext(ctx context.Context, params types.ReplaceTransactionParams) (string, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return "", err
+	}
+	params.Signer = signer
+	return c.client.SpeedUpTransaction(ctx, params)
+}
+func (c *WalletClient) CancelTransaction(params types.ReplaceTransactionParams) (string, error) {
+	return c.CancelTransactionContext(context.Background(), params)
+}
+func (c *WalletClient) CancelTransactionContext(ctx context.Context, params types.ReplaceTransactionParams) (string, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return "", err
+	}
+	params.Signer = signer
+	return c.client.CancelTransaction(ctx, params)
+}
+func (c *WalletClient) SignMessage(message []byte) (string, error) {
+	return c.SignMessageContext(context.Background(), message)
+}
+func (c *WalletClient) SignMessageContext(ctx context.Context, message []byte) (string, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return "", err
+	}
+	return signer.SignMessage(ctx, message)
+}
+func (c *WalletClient) SignTypedData(typedData types.TypedData) (string, error) {
+	return c.SignTypedDataContext(context.Background(), typedData)
+}
+func (c *WalletClient) SignTypedDataContext(ctx context.Context, typedData types.TypedData) (string, error) {
+	signer, err := requireSigner(c.signer)
+	if err != nil {
+		return "", err
+	}
+	return signer.SignTypedData(ctx, typedData)
 }
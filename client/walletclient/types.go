@@ -6,6 +6,6 @@ import (
 )
 
 type WalletClient struct {
-	client  *internal.RpcClient
-	account *types.Account
+	client *internal.RpcClient
+	signer types.Signer
 }
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// authorizationMagic prefixes the EIP-7702 authorization signing payload.
+const authorizationMagic = 0x05
+
+func authorizationHash(authorization types.Authorization) (common.Hash, error) {
+	chainId := authorization.ChainId
+	if chainId == nil {
+		chainId = new(big.Int)
+	}
+	payload, err := rlp.EncodeToBytes([]interface{}{
+		chainId,
+		common.HexToAddress(authorization.Address),
+		authorization.Nonce,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode authorization: %v", err)
+	}
+	return crypto.Keccak256Hash([]byte{authorizationMagic}, payload), nil
+}
+
+func SignAuthorization(authorization types.Authorization, privateKey *ecdsa.PrivateKey) (*types.SignedAuthorization, error) {
+	hash, err := authorizationHash(authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := crypto.Sign(hash.Bytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign authorization: %v", err)
+	}
+	return newSignedAuthorization(authorization, signature), nil
+}
+
+// newSignedAuthorization attaches a 65-byte signature with v set to 0 or 1.
+func newSignedAuthorization(authorization types.Authorization, signature []byte) *types.SignedAuthorization {
+	chainId := authorization.ChainId
+	if chainId == nil {
+		chainId = new(big.Int)
+	}
+	return &types.SignedAuthorization{
+		ChainId: chainId,
+		Address: common.HexToAddress(authorization.Address).Hex(),
+		Nonce:   authorization.Nonce,
+		YParity: signature[64],
+		R:       new(big.Int).SetBytes(signature[:32]),
+		S:       new(big.Int).SetBytes(signature[32:64]),
+	}
+}
+
+// RecoverAuthorizationAddress returns the authority that signed the
+// authorization.
+func RecoverAuthorizationAddress(authorization types.SignedAuthorization) (string, error) {
+	hash, err := authorizationHash(types.Authorization{
+		ChainId: authorization.ChainId,
+		Address: authorization.Address,
+		Nonce:   authorization.Nonce,
+	})
+	if err != nil {
+		return "", err
+	}
+	if authorization.YParity > 1 || authorization.R == nil || authorization.S == nil {
+		return "", fmt.Errorf("invalid authorization signature")
+	}
+
+	signature := make([]byte, 65)
+	authorization.R.FillBytes(signature[:32])
+	authorization.S.FillBytes(signature[32:64])
+	signature[64] = authorization.YParity
+
+	publicKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover authorization signer: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey).Hex(), nil
+}
+
+// PrepareAuthorization fills in the chain id and nonce of an authorization
+// signed by signer.
+func (c *RpcClient) PrepareAuthorization(ctx context.Context, params types.SignAuthorizationParams, signer types.Signer) (*types.Authorization, error) {
+	chainId := params.ChainId
+	if chainId == nil {
+		chainId = big.NewInt(c.chain.Id)
+	}
+
+	var nonce uint64
+	if params.Nonce != nil {
+		nonce = *params.Nonce
+	} else {
+		pendingNonce, err := c.getTransactionCount(ctx, signer.Address(), "pending")
+		if err != nil {
+			return nil, err
+		}
+		nonce = pendingNonce
+		// The authority's own transaction bumps its nonce before the
+		// authorization list is processed.
+		if params.Executor == types.AuthorizationExecutorSelf || (params.Executor != "" && common.HexToAddress(params.Executor) == common.HexToAddress(signer.Address())) {
+			nonce++
+		}
+	}
+
+	return &types.Authorization{
+		ChainId: chainId,
+		Address: params.ContractAddress,
+		Nonce:   nonce,
+	}, nil
+}
+
+func (c *RpcClient) SignAuthorization(ctx context.Context, params types.SignAuthorizationParams, signer types.Signer) (*types.SignedAuthorization, error) {
+	authorization, err := c.PrepareAuthorization(ctx, params, signer)
+	if err != nil {
+		return nil, err
+	}
+	return signAuthorization(ctx, *authorization, signer)
+}
+
+func signAuthorization(ctx context.Context, authorization types.Authorization, signer types.Signer) (*types.SignedAuthorization, error) {
+	hash, err := authorizationHash(authorization)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signDigest(ctx, signer, hash.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign authorization: %w", err)
+	}
+	return newSignedAuthorization(authorization, signature), nil
+}
+
+// withSelfAuthorizations signs the sender's own authorizations once the
+// transaction's nonce is known. The node bumps the sender's nonce for the
+// transaction before it applies the list, so they take the nonces after it.
+func (c *RpcClient) withSelfAuthorizations(ctx context.Context, params types.TxInteractionParams, reservedNonce *uint64) (*types.TxData, error) {
+	if len(params.TxData.SelfAuthorizations) == 0 {
+		return params.TxData, nil
+	}
+	if params.Signer == nil {
+		return nil, fmt.Errorf("self authorizations require a signer")
+	}
+
+	nonce, err := c.resolveNonce(ctx, params, reservedNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	txData := *params.TxData
+	txData.Nonce = &nonce
+	txData.AuthorizationList = append([]types.SignedAuthorization(nil), params.TxData.AuthorizationList...)
+	txData.SelfAuthorizations = nil
+	for i, authorization := range params.TxData.SelfAuthorizations {
+		if authorization.ChainId == nil {
+			authorization.ChainId = big.NewInt(c.chain.Id)
+		}
+		authorization.Nonce = nonce + 1 + uint64(i)
+		signed, err := signAuthorization(ctx, authorization, params.Signer)
+		if err != nil {
+			return nil, err
+		}
+		txData.AuthorizationList = append(txData.AuthorizationList, *signed)
+	}
+
+	return &txData, nil
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// setCodeTxFields mirrors the RLP payload of a signed set-code transaction.
+type setCodeTxFields struct {
+	ChainId           *big.Int
+	Nonce             uint64
+	GasTipCap         *big.Int
+	GasFeeCap         *big.Int
+	Gas               uint64
+	To                common.Address
+	Value             *big.Int
+	Data              []byte
+	AccessList        ethTypes.AccessList
+	AuthorizationList []rlpAuthorization
+	YParity           uint8
+	R                 *big.Int
+	S                 *big.Int
+}
+
+func testAccount(t *testing.T) *types.Account {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Account{Address: crypto.PubkeyToAddress(key.PublicKey).Hex(), PrivateKey: key}
+}
+
+func TestSignAuthorizationRoundTrip(t *testing.T) {
+	account := testAccount(t)
+	signed, err := SignAuthorization(types.Authorization{
+		ChainId: big.NewInt(1),
+		Address: "0x00000000000000000000000000000000000000cc",
+		Nonce:   3,
+	}, account.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authority, err := RecoverAuthorizationAddress(*signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authority != account.Address {
+		t.Errorf("recovered %s, want %s", authority, account.Address)
+	}
+}
+
+func TestSendTxSignsSelfAuthorizationsWithReservedNonce(t *testing.T) {
+	var sent setCodeTxFields
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		switch request.Method {
+		case "eth_getTransactionCount":
+			return rpcResult(request, "0x5")
+		case "eth_getBlockByNumber":
+			return rpcResult(request, map[string]string{"number": "0x1", "baseFeePerGas": "0x1"})
+		case "eth_feeHistory":
+			return rpcResult(request, map[string]interface{}{"reward": [][]string{{"0x1", "0x1", "0x1"}}, "baseFeePerGas": []string{"0x1"}})
+		case "eth_estimateGas":
+			return rpcResult(request, "0x10000")
+		case "eth_sendRawTransaction":
+			raw := hexutil.MustDecode(request.Params.([]interface{})[0].(string))
+			if raw[0] != setCodeTxType {
+				return rpcFailure(request, -32000, "not a set-code transaction")
+			}
+			if err := rlp.DecodeBytes(raw[1:], &sent); err != nil {
+				return rpcFailure(request, -32000, err.Error())
+			}
+			return rpcResult(request, crypto.Keccak256Hash(raw).Hex())
+		}
+		return rpcFailure(request, -32601, "method not found")
+	})
+
+	nonces := NewNonceManager()
+	c := NewRpcClient(types.NewRpcClientParams{
+		RpcUrl:       server.URL,
+		Chain:        types.Chain{Id: 1},
+		NonceManager: nonces,
+	})
+	account := testAccount(t)
+
+	// Another send holds nonce 5, which the node does not know about yet.
+	if _, err := nonces.Next(context.Background(), 1, account.Address, pendingNonce(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c.SendTx(context.Background(), types.TxInteractionParams{
+		Account: account,
+		TxData: &types.TxData{
+			To:                 account.Address,
+			SelfAuthorizations: []types.Authorization{{Address: "0x00000000000000000000000000000000000000cc"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sent.Nonce != 6 {
+		t.Fatalf("sent nonce %d, want 6", sent.Nonce)
+	}
+	if len(sent.AuthorizationList) != 1 {
+		t.Fatalf("sent %d authorizations, want 1", len(sent.AuthorizationList))
+	}
+	authorization := sent.AuthorizationList[0]
+	if authorization.Nonce != 7 || authorization.ChainId.Int64() != 1 {
+		t.Errorf("got authorization nonce %d chain %s, want 7 and 1", authorization.Nonce, authorization.ChainId)
+	}
+	authority, err := RecoverAuthorizationAddress(types.SignedAuthorization{
+		ChainId: authorization.ChainId,
+		Address: authorization.Address.Hex(),
+		Nonce:   authorization.Nonce,
+		YParity: authorization.YParity,
+		R:       authorization.R,
+		S:       authorization.S,
+	})
+	if err != nil || authority != account.Address {
+		t.Errorf("authorization signed by %s (%v), want %s", authority, err, account.Address)
+	}
+}
+
+func TestSendTxReservesSelfAuthorizationNonces(t *testing.T) {
+	var mu sync.Mutex
+	// The node enforces the account nonce but keeps reporting a pending
+	// nonce of 5, as a lagging node behind a load balancer does.
+	pending := uint64(5)
+	var sentNonces []uint64
+	rejected := 0
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		mu.Lock()
+		defer mu.Unlock()
+		switch request.Method {
+		case "eth_getTransactionCount":
+			return rpcResult(request, "0x5")
+		case "eth_getBlockByNumber":
+			return rpcResult(request, map[string]string{"number": "0x1", "baseFeePerGas": "0x1"})
+		case "eth_feeHistory":
+			return rpcResult(request, map[string]interface{}{"reward": [][]string{{"0x1", "0x1", "0x1"}}, "baseFeePerGas": []string{"0x1"}})
+		case "eth_estimateGas":
+			return rpcResult(request, "0x10000")
+		case "eth_sendRawTransaction":
+			raw := hexutil.MustDecode(request.Params.([]interface{})[0].(string))
+			var sent setCodeTxFields
+			if err := rlp.DecodeBytes(raw[1:], &sent); err != nil {
+				return rpcFailure(request, -32000, err.Error())
+			}
+			if sent.Nonce < pending {
+				rejected++
+				return rpcFailure(request, -32000, "nonce too low")
+			}
+			// The transaction and each of its authorizations bump the
+			// account nonce.
+			pending = sent.Nonce + 1 + uint64(len(sent.AuthorizationList))
+			sentNonces = append(sentNonces, sent.Nonce)
+			return rpcResult(request, crypto.Keccak256Hash(raw).Hex())
+		}
+		return rpcFailure(request, -32601, "method not found")
+	})
+
+	c := NewRpcClient(types.NewRpcClientParams{
+		RpcUrl:       server.URL,
+		Chain:        types.Chain{Id: 1},
+		NonceManager: NewNonceManager(),
+	})
+	account := testAccount(t)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.SendTx(context.Background(), types.TxInteractionParams{
+			Account: account,
+			TxData: &types.TxData{
+				To: account.Address,
+				SelfAuthorizations: []types.Authorization{
+					{Address: "0x00000000000000000000000000000000000000cc"},
+					{Address: "0x00000000000000000000000000000000000000dd"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if fmt.Sprint(sentNonces) != "[5 8]" {
+		t.Errorf("sent nonces %v, want [5 8]", sentNonces)
+	}
+	if rejected != 0 {
+		t.Errorf("the node rejected %d transactions as nonce too low", rejected)
+	}
+}
@@ -0,0 +1,214 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type pendingCall struct {
+	request  types.RpcRequest
+	response *types.RpcResponse
+	err      error
+	done     chan struct{}
+}
+
+func newPendingCall(request types.RpcRequest) *pendingCall {
+	return &pendingCall{request: request, done: make(chan struct{})}
+}
+
+func (p *pendingCall) wait(ctx context.Context) (*types.RpcResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.done:
+		return p.response, p.err
+	}
+}
+
+// sendBatch sends the calls as a single JSON-RPC array request and hands each
+// call its own response, matched back by id.
+func (c *RpcClient) sendBatch(ctx context.Context, calls []*pendingCall) error {
+	requests := make([]types.RpcRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.request
+	}
+
+	responses, err := c.transport.BatchRequest(ctx, requests)
+	if err != nil {
+		for _, call := range calls {
+			call.err = err
+			close(call.done)
+		}
+		return err
+	}
+
+	byId := make(map[int]*types.RpcResponse, len(responses))
+	for i := range responses {
+		byId[responses[i].Id] = &responses[i]
+	}
+	for _, call := range calls {
+		if response, ok := byId[call.request.Id]; ok {
+			call.response = response
+		} else {
+			call.err = fmt.Errorf("missing response for request %d (%s)", call.request.Id, call.request.Method)
+		}
+		close(call.done)
+	}
+
+	return nil
+}
+
+type batchCollectorKey struct{}
+
+// batchCollector gathers the calls made by a set of concurrently running
+// actions. Once every action still running is blocked on a call, the queued
+// calls are flushed as one batch, so actions that issue several calls in
+// sequence are batched round by round.
+type batchCollector struct {
+	client *RpcClient
+	ctx    context.Context
+
+	mu     sync.Mutex
+	active int
+	queued []*pendingCall
+	err    error
+}
+
+func (b *batchCollector) enqueue(call *pendingCall) {
+	b.mu.Lock()
+	b.queued = append(b.queued, call)
+	b.flushIfReady()
+}
+
+func (b *batchCollector) finish() {
+	b.mu.Lock()
+	b.active--
+	b.flushIfReady()
+}
+
+// flushIfReady must be called with b.mu held and releases it.
+func (b *batchCollector) flushIfReady() {
+	if len(b.queued) == 0 || len(b.queued) < b.active {
+		b.mu.Unlock()
+		return
+	}
+	calls := b.queued
+	b.queued = nil
+	b.mu.Unlock()
+
+	if err := b.client.sendBatch(b.ctx, calls); err != nil {
+		b.mu.Lock()
+		if b.err == nil {
+			b.err = err
+		}
+		b.mu.Unlock()
+	}
+}
+
+type Batch struct {
+	client  *RpcClient
+	actions []func(ctx context.Context)
+}
+
+func (c *RpcClient) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+func (b *Batch) Add(action func(ctx context.Context)) {
+	b.actions = append(b.actions, action)
+}
+
+// Send runs every queued action and sends their calls as JSON-RPC batches.
+// Per-call errors are reported through each action's result; the returned
+// error is the first transport failure, if any.
+func (b *Batch) Send(ctx context.Context) error {
+	actions := b.actions
+	b.actions = nil
+	if len(actions) == 0 {
+		return nil
+	}
+
+	collector := &batchCollector{client: b.client, ctx: ctx, active: len(actions)}
+	batchCtx := context.WithValue(ctx, batchCollectorKey{}, collector)
+
+	var wg sync.WaitGroup
+	for _, action := range actions {
+		wg.Add(1)
+		go func(action func(ctx context.Context)) {
+			defer wg.Done()
+			defer collector.finish()
+			action(batchCtx)
+		}(action)
+	}
+	wg.Wait()
+
+	if collector.err != nil {
+		return collector.err
+	}
+	return ctx.Err()
+}
+
+func AddToBatch[T any](batch *Batch, action func(ctx context.Context) (T, error)) *types.BatchResult[T] {
+	result := &types.BatchResult[T]{}
+	batch.Add(func(ctx context.Context) {
+		result.Value, result.Err = action(ctx)
+	})
+
+	return result
+}
+
+// autoBatcher coalesces calls issued within a short window into one batch.
+type autoBatcher struct {
+	client  *RpcClient
+	wait    time.Duration
+	maxSize int
+
+	mu     sync.Mutex
+	queued []*pendingCall
+	timer  *time.Timer
+}
+
+func newAutoBatcher(client *RpcClient, options types.BatchOptions) *autoBatcher {
+	return &autoBatcher{client: client, wait: options.Wait, maxSize: options.MaxSize}
+}
+
+func (a *autoBatcher) enqueue(call *pendingCall) {
+	a.mu.Lock()
+	a.queued = append(a.queued, call)
+	if a.maxSize > 0 && len(a.queued) >= a.maxSize {
+		calls := a.take()
+		a.mu.Unlock()
+		go a.client.sendBatch(context.Background(), calls)
+		return
+	}
+	if a.timer == nil {
+		a.timer = time.AfterFunc(a.wait, a.flush)
+	}
+	a.mu.Unlock()
+}
+
+func (a *autoBatcher) flush() {
+	a.mu.Lock()
+	calls := a.take()
+	a.mu.Unlock()
+
+	if len(calls) > 0 {
+		a.client.sendBatch(context.Background(), calls)
+	}
+}
+
+// take must be called with a.mu held.
+func (a *autoBatcher) take() []*pendingCall {
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	calls := a.queued
+	a.queued = nil
+
+	return calls
+}
@@ -0,0 +1,242 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// batchRecorder is a stand-in HTTP node that records the size of every
+// request it receives, 1 for a single call.
+type batchRecorder struct {
+	mu    sync.Mutex
+	sizes []int
+}
+
+func newBatchRecorder(t *testing.T, handler rpcHandler) (*batchRecorder, *httptest.Server) {
+	t.Helper()
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		size := 1
+		if message := bytes.TrimSpace(body.Bytes()); len(message) > 0 && message[0] == '[' {
+			var requests []json.RawMessage
+			json.Unmarshal(message, &requests)
+			size = len(requests)
+		}
+		recorder.mu.Lock()
+		recorder.sizes = append(recorder.sizes, size)
+		recorder.mu.Unlock()
+		w.Write(handleRpcMessage(handler, body.Bytes()))
+	}))
+	t.Cleanup(server.Close)
+	return recorder, server
+}
+
+func (r *batchRecorder) requestSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.sizes...)
+}
+
+func echoCall(c *RpcClient, param string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		result, err := c.Call(ctx, "test_echo", []interface{}{param})
+		if err != nil {
+			return "", err
+		}
+		var params []string
+		if err := json.Unmarshal(result, &params); err != nil || len(params) != 1 {
+			return "", fmt.Errorf("unexpected result %s", result)
+		}
+		return params[0], nil
+	}
+}
+
+func TestBatchMatchesResponsesById(t *testing.T) {
+	recorder, server := newBatchRecorder(t, echoHandler)
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+
+	batch := c.NewBatch()
+	results := make([]*types.BatchResult[string], 5)
+	for i := range results {
+		results[i] = AddToBatch(batch, echoCall(c, fmt.Sprint(i)))
+	}
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, result := range results {
+		if result.Err != nil || result.Value != fmt.Sprint(i) {
+			t.Errorf("result %d: got %q, %v", i, result.Value, result.Err)
+		}
+	}
+	if sizes := recorder.requestSizes(); len(sizes) != 1 || sizes[0] != 5 {
+		t.Errorf("got requests of sizes %v, want one batch of 5", sizes)
+	}
+}
+
+func TestBatchRunsSequentialCallsRoundByRound(t *testing.T) {
+	recorder, server := newBatchRecorder(t, echoHandler)
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+
+	batch := c.NewBatch()
+	sequential := AddToBatch(batch, func(ctx context.Context) (string, error) {
+		first, err := echoCall(c, "a")(ctx)
+		if err != nil {
+			return "", err
+		}
+		second, err := echoCall(c, "b")(ctx)
+		return first + second, err
+	})
+	single := AddToBatch(batch, echoCall(c, "c"))
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if sequential.Value != "ab" || single.Value != "c" {
+		t.Errorf("got %q and %q, want %q and %q", sequential.Value, single.Value, "ab", "c")
+	}
+	if sizes := recorder.requestSizes(); fmt.Sprint(sizes) != "[2 1]" {
+		t.Errorf("got requests of sizes %v, want [2 1]", sizes)
+	}
+}
+
+func TestBatchPerCallErrors(t *testing.T) {
+	_, server := newBatchRecorder(t, func(request types.RpcRequest) types.RpcResponse {
+		if request.Params.([]interface{})[0] == "bad" {
+			return rpcFailure(request, -32000, "execution reverted")
+		}
+		return echoHandler(request)
+	})
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+
+	batch := c.NewBatch()
+	good := AddToBatch(batch, echoCall(c, "good"))
+	bad := AddToBatch(batch, echoCall(c, "bad"))
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if good.Err != nil || good.Value != "good" {
+		t.Errorf("got %q, %v for the good call", good.Value, good.Err)
+	}
+	if bad.Err == nil || !strings.Contains(bad.Err.Error(), "execution reverted") {
+		t.Errorf("got error %v for the bad call", bad.Err)
+	}
+}
+
+func TestBatchMissingResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []types.RpcRequest
+		json.NewDecoder(r.Body).Decode(&requests)
+		json.NewEncoder(w).Encode([]types.RpcResponse{echoHandler(requests[0])})
+	}))
+	defer server.Close()
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+
+	batch := c.NewBatch()
+	first := AddToBatch(batch, func(ctx context.Context) (json.RawMessage, error) {
+		return c.Call(ctx, "test_echo", []interface{}{"a"})
+	})
+	second := AddToBatch(batch, func(ctx context.Context) (json.RawMessage, error) {
+		// Queue after the first call so its id is the one the node drops.
+		time.Sleep(10 * time.Millisecond)
+		return c.Call(ctx, "test_echo", []interface{}{"b"})
+	})
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Err != nil {
+		t.Errorf("got error %v for the answered call", first.Err)
+	}
+	if second.Err == nil || !strings.Contains(second.Err.Error(), "missing response") {
+		t.Errorf("got error %v, want a missing response", second.Err)
+	}
+}
+
+func TestBatchTransportFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer server.Close()
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+
+	batch := c.NewBatch()
+	result := AddToBatch(batch, echoCall(c, "a"))
+	err := batch.Send(context.Background())
+	if err == nil {
+		t.Fatal("expected the transport failure to be returned")
+	}
+	if result.Err == nil {
+		t.Error("expected the call to fail with the transport error")
+	}
+}
+
+func TestAutoBatcherCoalescesCalls(t *testing.T) {
+	recorder, server := newBatchRecorder(t, echoHandler)
+	c := NewRpcClient(types.NewRpcClientParams{
+		RpcUrl: server.URL,
+		Batch:  &types.BatchOptions{Wait: 20 * time.Millisecond},
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := echoCall(c, fmt.Sprint(i))(context.Background())
+			if err == nil && value != fmt.Sprint(i) {
+				err = fmt.Errorf("call %d got %q", i, value)
+			}
+			if err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if sizes := recorder.requestSizes(); fmt.Sprint(sizes) != "[4]" {
+		t.Errorf("got requests of sizes %v, want [4]", sizes)
+	}
+}
+
+func TestAutoBatcherMaxSize(t *testing.T) {
+	recorder, server := newBatchRecorder(t, echoHandler)
+	c := NewRpcClient(types.NewRpcClientParams{
+		RpcUrl: server.URL,
+		Batch:  &types.BatchOptions{Wait: time.Hour, MaxSize: 2},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := echoCall(c, fmt.Sprint(i))(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if sizes := recorder.requestSizes(); fmt.Sprint(sizes) != "[2 2]" {
+		t.Errorf("got requests of sizes %v, want [2 2]", sizes)
+	}
+}
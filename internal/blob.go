@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const (
+	fieldElementsPerBlob = 4096
+	bytesPerFieldElement = 32
+	// The leading byte of every field element stays zero so that its value
+	// is below the BLS modulus.
+	bytesPerBlobChunk = bytesPerFieldElement - 1
+	bytesPerBlob      = fieldElementsPerBlob * bytesPerBlobChunk
+	maxBlobsPerTx     = 6
+	// blobDataTerminator marks the end of the payload so it can be recovered
+	// from the zero padding.
+	blobDataTerminator = 0x80
+)
+
+// ToBlobs packs data into blobs, 31 bytes per field element, followed by a
+// terminator byte.
+func ToBlobs(data []byte) ([]kzg4844.Blob, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("blob data is empty")
+	}
+
+	payload := append(append([]byte{}, data...), blobDataTerminator)
+	blobCount := (len(payload) + bytesPerBlob - 1) / bytesPerBlob
+	if blobCount > maxBlobsPerTx {
+		return nil, fmt.Errorf("blob data too large: %d bytes needs %d blobs, max %d", len(data), blobCount, maxBlobsPerTx)
+	}
+
+	blobs := make([]kzg4844.Blob, blobCount)
+	for i := range blobs {
+		chunk := payload[i*bytesPerBlob : min((i+1)*bytesPerBlob, len(payload))]
+		for j := 0; j*bytesPerBlobChunk < len(chunk); j++ {
+			element := chunk[j*bytesPerBlobChunk : min((j+1)*bytesPerBlobChunk, len(chunk))]
+			copy(blobs[i][j*bytesPerFieldElement+1:], element)
+		}
+	}
+	return blobs, nil
+}
+
+// FromBlobs reverses ToBlobs.
+func FromBlobs(blobs []kzg4844.Blob) ([]byte, error) {
+	data := make([]byte, 0, len(blobs)*bytesPerBlob)
+	for _, blob := range blobs {
+		for j := 0; j < fieldElementsPerBlob; j++ {
+			data = append(data, blob[j*bytesPerFieldElement+1:(j+1)*bytesPerFieldElement]...)
+		}
+	}
+
+	for i := len(data) - 1; i >= 0; i-- {
+		if data[i] == blobDataTerminator {
+			return data[:i], nil
+		}
+		if data[i] != 0 {
+			break
+		}
+	}
+	return nil, fmt.Errorf("blob data terminator not found")
+}
+
+// BlobsToSidecar computes the KZG commitments and proofs for blobs.
+func BlobsToSidecar(blobs []kzg4844.Blob) (*ethTypes.BlobTxSidecar, error) {
+	sidecar := &ethTypes.BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, len(blobs)),
+	}
+	for i := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(&blobs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob commitment: %v", err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(&blobs[i], commitment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob proof: %v", err)
+		}
+		sidecar.Commitments[i] = commitment
+		sidecar.Proofs[i] = proof
+	}
+	return sidecar, nil
+}
+
+// BlobVersionedHashes returns the versioned hashes of the sidecar's
+// commitments as hex strings.
+func BlobVersionedHashes(sidecar *ethTypes.BlobTxSidecar) []string {
+	hashes := make([]string, 0, len(sidecar.Commitments))
+	hasher := sha256.New()
+	for i := range sidecar.Commitments {
+		hashes = append(hashes, fmt.Sprintf("0x%x", kzg4844.CalcBlobHashV1(hasher, &sidecar.Commitments[i])))
+	}
+	return hashes
+}
+
+func (c *RpcClient) GetBlobBaseFee(ctx context.Context) (*big.Int, error) {
+	result, err := c.Call(ctx, "eth_blobBaseFee", []interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob base fee: %w", err)
+	}
+
+	var blobBaseFeeHex string
+	if err := json.Unmarshal(result, &blobBaseFeeHex); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal blobBaseFeeHex: %v", err)
+	}
+
+	return HexToBigInt(blobBaseFeeHex), nil
+}
+
+func (c *RpcClient) buildBlobTx(ctx context.Context, txData *types.TxData, nonce uint64, gasLimit uint64, gasTipCap *big.Int, gasFeeCap *big.Int, accessList ethTypes.AccessList) (*ethTypes.BlobTx, error) {
+	if txData.To == "" {
+		return nil, fmt.Errorf("blob transactions require a recipient")
+	}
+
+	blobs, err := ToBlobs(txData.BlobData)
+	if err != nil {
+		return nil, err
+	}
+	sidecar, err := BlobsToSidecar(blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	blobFeeCap := txData.MaxFeePerBlobGas
+	if blobFeeCap == nil {
+		blobBaseFee, err := c.GetBlobBaseFee(ctx)
+		if err != nil {
+			return nil, err
+		}
+		blobFeeCap = blobBaseFee.Mul(blobBaseFee, big.NewInt(2))
+	}
+
+	chainId := txData.ChainId
+	if chainId == nil {
+		chainId = big.NewInt(c.chain.Id)
+	}
+
+	chainIdValue, err := toUint256("chain id", chainId)
+	if err != nil {
+		return nil, err
+	}
+	gasTipCapValue, err := toUint256("max priority fee per gas", gasTipCap)
+	if err != nil {
+		return nil, err
+	}
+	gasFeeCapValue, err := toUint256("max fee per gas", gasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+	value, err := toUint256("value", orZero(txData.Value))
+	if err != nil {
+		return nil, err
+	}
+	blobFeeCapValue, err := toUint256("max fee per blob gas", blobFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethTypes.BlobTx{
+		ChainID:    chainIdValue,
+		Nonce:      nonce,
+		GasTipCap:  gasTipCapValue,
+		GasFeeCap:  gasFeeCapValue,
+		Gas:        gasLimit,
+		To:         common.HexToAddress(txData.To),
+		Value:      value,
+		Data:       txData.Data,
+		AccessList: accessList,
+		BlobFeeCap: blobFeeCapValue,
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	}, nil
+}
+
+// toUint256 converts a blob transaction field, rejecting values that are
+// negative or do not fit in 256 bits.
+func toUint256(name string, value *big.Int) (*uint256.Int, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("%s cannot be negative: %s", name, value)
+	}
+	converted, overflow := uint256.FromBig(value)
+	if overflow {
+		return nil, fmt.Errorf("%s does not fit in 256 bits: %s", name, value)
+	}
+	return converted, nil
+}
+
+func orZero(value *big.Int) *big.Int {
+	if value == nil {
+		return new(big.Int)
+	}
+	return value
+}
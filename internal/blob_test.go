@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+func TestBlobsRoundTrip(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	payload := func(size int) []byte {
+		data := make([]byte, size)
+		random.Read(data)
+		// Trailing zeros and terminator bytes in the data must survive.
+		if size >= 2 {
+			data[size-2] = blobDataTerminator
+			data[size-1] = 0
+		}
+		return data
+	}
+
+	tests := []struct {
+		name  string
+		size  int
+		blobs int
+	}{
+		{"one byte", 1, 1},
+		{"exactly one blob", bytesPerBlob - 1, 1},
+		{"one byte over one blob", bytesPerBlob, 2},
+		{"exactly max blobs", maxBlobsPerTx*bytesPerBlob - 1, maxBlobsPerTx},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := payload(test.size)
+			blobs, err := ToBlobs(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(blobs) != test.blobs {
+				t.Fatalf("got %d blobs, want %d", len(blobs), test.blobs)
+			}
+			for i := range blobs {
+				for j := 0; j < fieldElementsPerBlob; j++ {
+					if high := blobs[i][j*bytesPerFieldElement]; high != 0 {
+						t.Fatalf("blob %d field element %d has high byte %#x", i, j, high)
+					}
+				}
+			}
+
+			decoded, err := FromBlobs(blobs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Errorf("got %d bytes back, want the original %d", len(decoded), len(data))
+			}
+		})
+	}
+}
+
+func TestToBlobsLimits(t *testing.T) {
+	if _, err := ToBlobs(nil); err == nil {
+		t.Error("expected an error for empty data")
+	}
+	if _, err := ToBlobs(make([]byte, maxBlobsPerTx*bytesPerBlob)); err == nil {
+		t.Error("expected an error for data over the blob limit")
+	}
+}
+
+func TestFromBlobsWithoutTerminator(t *testing.T) {
+	blobs := make([]kzg4844.Blob, 1)
+	if _, err := FromBlobs(blobs); err == nil {
+		t.Error("expected an error for an empty blob")
+	}
+	blobs[0][1] = 0x01
+	if _, err := FromBlobs(blobs); err == nil {
+		t.Error("expected an error for a blob without a terminator")
+	}
+}
+
+func TestBuildBlobTxRejectsOutOfRangeValues(t *testing.T) {
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: "http://127.0.0.1:0"})
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 256)
+	fee := big.NewInt(1e9)
+
+	tests := map[string]struct {
+		txData    types.TxData
+		gasFeeCap *big.Int
+		want      string
+	}{
+		"negative value":    {types.TxData{Value: big.NewInt(-1)}, fee, "value cannot be negative"},
+		"large chain id":    {types.TxData{ChainId: tooLarge}, fee, "chain id does not fit"},
+		"large fee cap":     {types.TxData{}, tooLarge, "max fee per gas does not fit"},
+		"large blob fee":    {types.TxData{MaxFeePerBlobGas: tooLarge}, fee, "max fee per blob gas does not fit"},
+		"negative blob fee": {types.TxData{MaxFeePerBlobGas: big.NewInt(-1)}, fee, "max fee per blob gas cannot be negative"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			txData := test.txData
+			txData.To = "0x000000000000000000000000000000000000dEaD"
+			txData.BlobData = []byte("blob")
+			if txData.ChainId == nil {
+				txData.ChainId = big.NewInt(1)
+			}
+			if txData.MaxFeePerBlobGas == nil {
+				txData.MaxFeePerBlobGas = fee
+			}
+
+			_, err := c.buildBlobTx(context.Background(), &txData, 0, 21000, fee, test.gasFeeCap, nil)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
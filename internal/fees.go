@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const (
+	defaultFeeHistoryBlockCount = 10
+	defaultBaseFeeMultiplier    = 2
+)
+
+var defaultFeePercentiles = [3]float64{10, 50, 90}
+
+// EstimateFeesPerGas suggests EIP-1559 fees from the base fee of the next
+// block and the priority fees paid over the last BlockCount blocks.
+func (c *RpcClient) EstimateFeesPerGas(ctx context.Context, params types.EstimateFeesPerGasParams) (*types.FeeEstimate, error) {
+	blockCount := params.BlockCount
+	if blockCount == 0 {
+		blockCount = defaultFeeHistoryBlockCount
+	}
+	percentiles := defaultFeePercentiles
+	if params.Percentiles != nil {
+		percentiles = *params.Percentiles
+	}
+	multiplier := params.BaseFeeMultiplier
+	if multiplier == 0 {
+		multiplier = defaultBaseFeeMultiplier
+	}
+
+	feeHistory, err := c.GetFeeHistory(ctx, types.GetFeeHistoryParams{
+		BlockCount:        blockCount,
+		RewardPercentiles: percentiles[:],
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(feeHistory.BaseFeePerGas) == 0 {
+		return nil, fmt.Errorf("fee history has no base fees")
+	}
+
+	// The last base fee is the one of the block after the newest in the
+	// window.
+	baseFee := feeHistory.BaseFeePerGas[len(feeHistory.BaseFeePerGas)-1]
+
+	var tips [3]*big.Int
+	for i := range tips {
+		tips[i] = averageReward(feeHistory.Reward, i)
+	}
+	// Keep the suggestions ordered even when sparse blocks skew the averages.
+	if tips[1].Cmp(tips[0]) < 0 {
+		tips[1] = new(big.Int).Set(tips[0])
+	}
+	if tips[2].Cmp(tips[1]) < 0 {
+		tips[2] = new(big.Int).Set(tips[1])
+	}
+
+	var tip *big.Int
+	switch params.Speed {
+	case types.FeeSpeedSlow:
+		tip = tips[0]
+	case types.FeeSpeedFast:
+		tip = tips[2]
+	case types.FeeSpeedNormal, "":
+		tip = tips[1]
+	default:
+		return nil, fmt.Errorf("unsupported fee speed: %s", params.Speed)
+	}
+
+	paddedBaseFee, _ := new(big.Float).Mul(new(big.Float).SetInt(baseFee), big.NewFloat(multiplier)).Int(nil)
+
+	return &types.FeeEstimate{
+		BaseFeePerGas:           baseFee,
+		SlowPriorityFeePerGas:   tips[0],
+		NormalPriorityFeePerGas: tips[1],
+		FastPriorityFeePerGas:   tips[2],
+		MaxPriorityFeePerGas:    new(big.Int).Set(tip),
+		MaxFeePerGas:            paddedBaseFee.Add(paddedBaseFee, tip),
+	}, nil
+}
+
+func (c *RpcClient) GetFeeHistory(ctx context.Context, params types.GetFeeHistoryParams) (*types.FeeHistory, error) {
+	newestBlock := "latest"
+	if params.NewestBlock != nil {
+		newestBlock = fmt.Sprintf("0x%x", params.NewestBlock)
+	} else if params.NewestBlockTag != nil {
+		newestBlock = *params.NewestBlockTag
+	}
+	percentiles := params.RewardPercentiles
+	if percentiles == nil {
+		percentiles = []float64{}
+	}
+
+	result, err := c.Call(ctx, "eth_feeHistory", []interface{}{
+		fmt.Sprintf("0x%x", params.BlockCount),
+		newestBlock,
+		percentiles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+
+	var rawFeeHistory types.RawFeeHistory
+	if err := json.Unmarshal(result, &rawFeeHistory); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feeHistory: %v", err)
+	}
+
+	feeHistory := ConvertRawFeeHistory(rawFeeHistory)
+	return &feeHistory, nil
+}
+
+// averageReward averages the reward at the given percentile index over the
+// blocks that report one.
+func averageReward(rewards [][]*big.Int, index int) *big.Int {
+	sum := new(big.Int)
+	count := int64(0)
+	for _, blockRewards := range rewards {
+		if index >= len(blockRewards) {
+			continue
+		}
+		sum.Add(sum, blockRewards[index])
+		count++
+	}
+	if count == 0 {
+		return sum
+	}
+	return sum.Div(sum, big.NewInt(count))
+}
+
+type feeHistoryStrategy struct {
+	params types.EstimateFeesPerGasParams
+}
+
+// NewFeeHistoryStrategy returns a fee strategy that uses EstimateFeesPerGas
+// with the given parameters.
+func NewFeeHistoryStrategy(params types.EstimateFeesPerGasParams) types.FeeStrategy {
+	return &feeHistoryStrategy{params: params}
+}
+
+func (s *feeHistoryStrategy) FeesPerGas(ctx context.Context, estimate func(ctx context.Context, params types.EstimateFeesPerGasParams) (*types.FeeEstimate, error)) (*types.FeeEstimate, error) {
+	return estimate(ctx, s.params)
+}
+
+// resolveFees fills in whichever of the tip and max fee is unset from the fee
+// strategy, keeping the strategy's base fee headroom when only the tip is
+// given.
+func (c *RpcClient) resolveFees(ctx context.Context, maxFeePerGas *big.Int, maxPriorityFeePerGas *big.Int) (*big.Int, *big.Int, error) {
+	if maxFeePerGas != nil && maxPriorityFeePerGas != nil {
+		return maxFeePerGas, maxPriorityFeePerGas, nil
+	}
+
+	estimate, err := c.fees.FeesPerGas(ctx, c.EstimateFeesPerGas)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasTipCap := maxPriorityFeePerGas
+	if gasTipCap == nil {
+		gasTipCap = estimate.MaxPriorityFeePerGas
+		if maxFeePerGas != nil && gasTipCap.Cmp(maxFeePerGas) > 0 {
+			gasTipCap = maxFeePerGas
+		}
+	}
+
+	gasFeeCap := maxFeePerGas
+	if gasFeeCap == nil {
+		baseFeeHeadroom := new(big.Int).Sub(estimate.MaxFeePerGas, estimate.MaxPriorityFeePerGas)
+		if baseFeeHeadroom.Sign() < 0 {
+			baseFeeHeadroom.SetInt64(0)
+		}
+		gasFeeCap = baseFeeHeadroom.Add(baseFeeHeadroom, gasTipCap)
+	}
+
+	return gasFeeCap, gasTipCap, nil
+}
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+func (c *RpcClient) GetLogs(ctx context.Context, params types.GetLogsParams) ([]types.Log, error) {
+	result, err := c.Call(ctx, "eth_getLogs", []interface{}{params})
+	if err != nil {
+		return nil, err
+	}
+
+	var rawLogs []types.RawLog
+	if err := json.Unmarshal(result, &rawLogs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rawLogs: %v", err)
+	}
+
+	logs := make([]types.Log, len(rawLogs))
+	for i, rawLog := range rawLogs {
+		logs[i] = ConvertRawLog(rawLog)
+	}
+
+	return logs, nil
+}
+
+func (c *RpcClient) GetContractEvents(ctx context.Context, params types.GetContractEventsParams) ([]types.ContractEvent, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(params.Abi))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	event, ok := parsedABI.Events[params.EventName]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", params.EventName)
+	}
+
+	topics, err := eventTopics(event, params.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := c.GetLogs(ctx, types.GetLogsParams{
+		Address:      params.Address,
+		Topics:       topics,
+		FromBlock:    params.FromBlock,
+		FromBlockTag: params.FromBlockTag,
+		ToBlock:      params.ToBlock,
+		ToBlockTag:   params.ToBlockTag,
+		BlockHash:    params.BlockHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]types.ContractEvent, 0, len(logs))
+	for _, log := range logs {
+		decoded, err := decodeEventLog(event, log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *decoded)
+	}
+
+	return events, nil
+}
+
+// eventTopics builds the topic filter for event, narrowing indexed
+// arguments to the values given in args.
+func eventTopics(event abi.Event, args map[string]interface{}) ([][]string, error) {
+	// Only indexed inputs can be filtered by the node; anything else would
+	// silently widen the query.
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		input, ok := eventInput(event, name)
+		if !ok {
+			return nil, fmt.Errorf("event %s has no input named %q", event.Name, name)
+		}
+		if !input.Indexed {
+			return nil, fmt.Errorf("input %q of event %s is not indexed and cannot be filtered", name, event.Name)
+		}
+	}
+
+	var query [][]interface{}
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+
+		var rules []interface{}
+		switch value := args[input.Name].(type) {
+		case nil:
+		case []interface{}:
+			rules = convertArgs(value)
+		default:
+			rules = convertArgs([]interface{}{value})
+		}
+		query = append(query, rules)
+	}
+
+	hashes, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build topics: %v", err)
+	}
+
+	var topics [][]string
+	if !event.Anonymous {
+		topics = append(topics, []string{event.ID.Hex()})
+	}
+	for _, alternatives := range hashes {
+		var position []string
+		for _, hash := range alternatives {
+			position = append(position, hash.Hex())
+		}
+		topics = append(topics, position)
+	}
+
+	for len(topics) > 0 && topics[len(topics)-1] == nil {
+		topics = topics[:len(topics)-1]
+	}
+
+	return topics, nil
+}
+
+func eventInput(event abi.Event, name string) (abi.Argument, bool) {
+	for _, input := range event.Inputs {
+		if input.Name == name {
+			return input, true
+		}
+	}
+	return abi.Argument{}, false
+}
+
+func decodeEventLog(event abi.Event, log types.Log) (*types.ContractEvent, error) {
+	topics := make([]common.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = common.HexToHash(topic)
+	}
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return nil, fmt.Errorf("log %s:%d is not a %s event", log.TransactionHash, log.LogIndex, event.Name)
+		}
+		topics = topics[1:]
+	}
+
+	args := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(args, common.FromHex(log.Data)); err != nil {
+		return nil, fmt.Errorf("failed to unpack event data: %v", err)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, topics); err != nil {
+		return nil, fmt.Errorf("failed to parse event topics: %v", err)
+	}
+
+	return &types.ContractEvent{
+		EventName: event.Name,
+		Args:      args,
+		Log:       log,
+	}, nil
+}
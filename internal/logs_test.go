@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const transferEventAbi = `[{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+	{"name":"from","type":"address","indexed":true},
+	{"name":"to","type":"address","indexed":true},
+	{"name":"value","type":"uint256","indexed":false}]}]`
+
+func transferEvent(t *testing.T) abi.Event {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(transferEventAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Events["Transfer"]
+}
+
+func TestEventTopics(t *testing.T) {
+	event := transferEvent(t)
+
+	topics, err := eventTopics(event, map[string]interface{}{
+		"to": "0x00000000000000000000000000000000000000bb",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 3 || topics[0][0] != event.ID.Hex() || topics[1] != nil ||
+		topics[2][0] != "0x00000000000000000000000000000000000000000000000000000000000000bb" {
+		t.Errorf("got topics %v", topics)
+	}
+}
+
+func TestEventTopicsRejectsUnfilterableArgs(t *testing.T) {
+	event := transferEvent(t)
+	tests := map[string]string{
+		"recipient": `no input named "recipient"`,
+		"value":     `"value" of event Transfer is not indexed`,
+	}
+	for name, want := range tests {
+		_, err := eventTopics(event, map[string]interface{}{name: big.NewInt(1)})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got error %v, want %q", name, err, want)
+		}
+	}
+}
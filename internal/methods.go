@@ -1,8 +1,10 @@
 package internal
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -15,7 +17,7 @@ import (
 	"github.com/sunsetlover36/mjolnir/types"
 )
 
-func (c *RpcClient) GetBlock(params types.GetBlockParams) (*types.Block, error) {
+func (c *RpcClient) GetBlock(ctx context.Context, params types.GetBlockParams) (*types.Block, error) {
 	var rpcMethod string
 	var rpcParams []interface{}
 	if params.BlockHash != nil {
@@ -34,7 +36,7 @@ func (c *RpcClient) GetBlock(params types.GetBlockParams) (*types.Block, error)
 
 	rpcParams = append(rpcParams, true)
 
-	result, err := c.Call(rpcMethod, rpcParams)
+	result, err := c.Call(ctx, rpcMethod, rpcParams)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +54,7 @@ func (c *RpcClient) GetBlock(params types.GetBlockParams) (*types.Block, error)
 		GasLimit:         HexToBigInt(rawBlock.GasLimit),
 		GasUsed:          HexToBigInt(rawBlock.GasUsed),
 		Timestamp:        HexToBigInt(rawBlock.Timestamp),
+		BaseFeePerGas:    hexToOptionalBigInt(rawBlock.BaseFeePerGas),
 		Hash:             rawBlock.Hash,
 		ParentHash:       rawBlock.ParentHash,
 		Nonce:            rawBlock.Nonce,
@@ -71,8 +74,8 @@ func (c *RpcClient) GetBlock(params types.GetBlockParams) (*types.Block, error)
 	return &block, nil
 }
 
-func (c *RpcClient) GetBlockNumber() (uint64, error) {
-	result, err := c.Call("eth_blockNumber", []interface{}{})
+func (c *RpcClient) GetBlockNumber(ctx context.Context) (uint64, error) {
+	result, err := c.Call(ctx, "eth_blockNumber", []interface{}{})
 	if err != nil {
 		return 0, err
 	}
@@ -90,7 +93,7 @@ func (c *RpcClient) GetBlockNumber() (uint64, error) {
 	return blockNumber, nil
 }
 
-func (c *RpcClient) GetBlockTransactionCount(params types.GetBlockTransactionCountParams) (uint64, error) {
+func (c *RpcClient) GetBlockTransactionCount(ctx context.Context, params types.GetBlockTransactionCountParams) (uint64, error) {
 	var rpcParams []interface{}
 	if params.BlockHash != nil {
 		rpcParams = append(rpcParams, params.BlockHash)
@@ -102,7 +105,7 @@ func (c *RpcClient) GetBlockTransactionCount(params types.GetBlockTransactionCou
 		rpcParams = append(rpcParams, "latest")
 	}
 
-	result, err := c.Call("eth_getBlockTransactionCountByHash", rpcParams)
+	result, err := c.Call(ctx, "eth_getBlockTransactionCountByHash", rpcParams)
 	if err != nil {
 		return 0, err
 	}
@@ -120,8 +123,8 @@ func (c *RpcClient) GetBlockTransactionCount(params types.GetBlockTransactionCou
 	return txCount, nil
 }
 
-func (c *RpcClient) GetBalance(address string) (*big.Int, error) {
-	result, err := c.Call("eth_getBalance", []interface{}{address, "latest"})
+func (c *RpcClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	result, err := c.Call(ctx, "eth_getBalance", []interface{}{address, "latest"})
 	if err != nil {
 		return nil, err
 	}
@@ -137,8 +140,11 @@ func (c *RpcClient) GetBalance(address string) (*big.Int, error) {
 	return balance, nil
 }
 
-func (c *RpcClient) GetTransactionCount(address string) (uint64, error) {
-	result, err := c.Call("eth_getTransactionCount", []interface{}{address, "latest"})
+func (c *RpcClient) GetTransactionCount(ctx context.Context, address string) (uint64, error) {
+	return c.getTransactionCount(ctx, address, "latest")
+}
+func (c *RpcClient) getTransactionCount(ctx context.Context, address string, blockTag string) (uint64, error) {
+	result, err := c.Call(ctx, "eth_getTransactionCount", []interface{}{address, blockTag})
 	if err != nil {
 		return 0, err
 	}
@@ -156,8 +162,44 @@ func (c *RpcClient) GetTransactionCount(address string) (uint64, error) {
 	return transactionCount, nil
 }
 
-func (c *RpcClient) GetGasPrice() (*big.Int, error) {
-	result, err := c.Call("eth_gasPrice", []interface{}{})
+func (c *RpcClient) GetTransaction(ctx context.Context, hash string) (*types.Transaction, error) {
+	result, err := c.Call(ctx, "eth_getTransactionByHash", []interface{}{hash})
+	if err != nil {
+		return nil, err
+	}
+
+	var rawTx *types.RawTransaction
+	if err := json.Unmarshal(result, &rawTx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rawTx: %v", err)
+	}
+	if rawTx == nil {
+		return nil, fmt.Errorf("%w: %s", types.ErrTransactionNotFound, hash)
+	}
+
+	tx := ConvertRawTransaction(*rawTx)
+	return &tx, nil
+}
+
+func (c *RpcClient) GetTransactionReceipt(ctx context.Context, hash string) (*types.Receipt, error) {
+	result, err := c.Call(ctx, "eth_getTransactionReceipt", []interface{}{hash})
+	if err != nil {
+		return nil, err
+	}
+
+	var rawReceipt *types.RawReceipt
+	if err := json.Unmarshal(result, &rawReceipt); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rawReceipt: %v", err)
+	}
+	if rawReceipt == nil {
+		return nil, fmt.Errorf("%w: %s", types.ErrTransactionReceiptNotFound, hash)
+	}
+
+	receipt := ConvertRawReceipt(*rawReceipt)
+	return &receipt, nil
+}
+
+func (c *RpcClient) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	result, err := c.Call(ctx, "eth_gasPrice", []interface{}{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
@@ -172,19 +214,19 @@ func (c *RpcClient) GetGasPrice() (*big.Int, error) {
 
 	return gasPrice, nil
 }
-func (c *RpcClient) GetMaxPriorityFeePerGas() (*big.Int, error) {
+func (c *RpcClient) GetMaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
 	params := []interface{}{
 		"0xA",
 		"latest",
 		[]float64{90.0},
 	}
 
-	result, err := c.Call("eth_feeHistory", params)
+	result, err := c.Call(ctx, "eth_feeHistory", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get fee history: %w", err)
 	}
 
-	var feeHistory types.FeeHistoryResult
+	var feeHistory types.RawFeeHistory
 	if err := json.Unmarshal(result, &feeHistory); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal feeHistory: %v", err)
 	}
@@ -197,10 +239,10 @@ func (c *RpcClient) GetMaxPriorityFeePerGas() (*big.Int, error) {
 
 	return suggestedPriorityFee, nil
 }
-func (c *RpcClient) EstimateGas(params types.CallParams) (*big.Int, error) {
-	result, err := c.Call("eth_estimateGas", []types.CallParams{params})
+func (c *RpcClient) EstimateGas(ctx context.Context, params types.CallParams) (*big.Int, error) {
+	result, err := c.Call(ctx, "eth_estimateGas", []types.CallParams{params})
 	if err != nil {
-		return nil, fmt.Errorf("failed to estimate gas: %v", err)
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
 	}
 
 	var estimatedGasHex string
@@ -214,100 +256,397 @@ func (c *RpcClient) EstimateGas(params types.CallParams) (*big.Int, error) {
 	return estimatedGas, nil
 }
 
-func (c *RpcClient) PrepareTxRequest(params types.TxInteractionParams) (*ethTypes.Transaction, error) {
+func (c *RpcClient) PrepareTxRequest(ctx context.Context, params types.TxInteractionParams) (*ethTypes.Transaction, error) {
+	params.Signer = resolveSigner(params.Signer, params.Account)
+	return c.prepareTx(ctx, params, nil)
+}
+
+// prepareTx builds and signs the transaction, using reservedNonce when the
+// nonce was already taken from the nonce manager.
+func (c *RpcClient) prepareTx(ctx context.Context, params types.TxInteractionParams, reservedNonce *uint64) (*ethTypes.Transaction, error) {
+	if isSetCodeTx(params.TxData) {
+		return nil, fmt.Errorf("set-code transactions cannot be returned as go-ethereum transactions, use SendTx")
+	}
+	tx, err := c.prepareUnsignedTx(ctx, params, reservedNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Signer != nil {
+		signedTx, err := params.Signer.SignTransaction(ctx, tx, big.NewInt(c.chain.Id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		tx = signedTx
+	}
+
+	return tx, nil
+}
+
+// signRawTx prepares and signs the transaction and returns its network
+// encoding and hash.
+func (c *RpcClient) signRawTx(ctx context.Context, params types.TxInteractionParams, reservedNonce *uint64) ([]byte, common.Hash, error) {
+	if !isSetCodeTx(params.TxData) {
+		tx, err := c.prepareTx(ctx, params, reservedNonce)
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, common.Hash{}, fmt.Errorf("failed to marshal signed transaction: %w", err)
+		}
+		return data, tx.Hash(), nil
+	}
+
+	if params.Signer == nil {
+		return nil, common.Hash{}, fmt.Errorf("set-code transactions require a signer")
+	}
+	txData, err := c.withSelfAuthorizations(ctx, params, reservedNonce)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	params.TxData = txData
+	tx, err := c.prepareUnsignedTx(ctx, params, reservedNonce)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	chainId := params.TxData.ChainId
+	if chainId == nil {
+		chainId = big.NewInt(c.chain.Id)
+	}
+	return signSetCodeTx(ctx, tx, chainId, params.TxData.AuthorizationList, params.Signer)
+}
+
+// prepareUnsignedTx resolves the nonce, type, fees and gas of the
+// transaction. Set-code transactions come back as dynamic fee transactions
+// carrying the same fields.
+func (c *RpcClient) prepareUnsignedTx(ctx context.Context, params types.TxInteractionParams, reservedNonce *uint64) (*ethTypes.Transaction, error) {
 	toAddress := common.HexToAddress(params.TxData.To)
+	var from string
+	if params.Signer != nil {
+		from = params.Signer.Address()
+	}
 
-	nonce := params.TxData.Nonce
-	if nonce == 0 {
-		fetchedNonce, err := c.GetTransactionCount(params.Account.Address)
+	nonce, err := c.resolveNonce(ctx, params, reservedNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.AutoAccessList && params.TxData.AccessList == nil && params.TxData.Type != types.TxTypeLegacy {
+		txData, err := c.withAccessList(ctx, from, params.TxData)
 		if err != nil {
 			return nil, err
 		}
-		nonce = fetchedNonce
+		params.TxData = txData
+	}
+
+	txType, err := c.resolveTxType(ctx, params.TxData)
+	if err != nil {
+		return nil, err
 	}
 
-	gasTipCap := params.TxData.MaxPriorityFeePerGas
-	if gasTipCap == nil {
-		fetchedGasTipCap, err := c.GetMaxPriorityFeePerGas()
+	var gasPrice, gasTipCap, gasFeeCap *big.Int
+	if txType == types.TxTypeEip1559 || txType == types.TxTypeEip4844 || txType == types.TxTypeEip7702 {
+		gasFeeCap, gasTipCap, err = c.resolveFees(ctx, params.TxData.MaxFeePerGas, params.TxData.MaxPriorityFeePerGas)
 		if err != nil {
 			return nil, err
 		}
-		gasTipCap = fetchedGasTipCap
+		gasPrice = gasFeeCap
+	} else {
+		gasPrice = params.TxData.GasPrice
+		if gasPrice == nil {
+			fetchedGasPrice, err := c.GetGasPrice(ctx)
+			if err != nil {
+				return nil, err
+			}
+			gasPrice = fetchedGasPrice
+		}
 	}
 
-	gasFeeCap := params.TxData.MaxFeePerGas
-	if gasFeeCap == nil {
-		fetchedGasFeeCap, err := c.GetGasPrice()
+	accessList := toEthAccessList(params.TxData.AccessList)
+
+	var gasLimit uint64
+	if params.TxData.Gas != nil {
+		gasLimit = *params.TxData.Gas
+	} else {
+		estimatedGas, err := c.EstimateGas(ctx, types.CallParams{
+			From:              from,
+			To:                params.TxData.To,
+			GasPrice:          gasPrice,
+			Value:             params.TxData.Value,
+			Data:              params.TxData.Data,
+			AccessList:        params.TxData.AccessList,
+			AuthorizationList: params.TxData.AuthorizationList,
+		})
 		if err != nil {
 			return nil, err
 		}
-		gasFeeCap = fetchedGasFeeCap.Add(fetchedGasFeeCap, gasTipCap)
+		gasLimit = estimatedGas.Uint64()
 	}
 
-	gasLimit := params.TxData.Gas
-	if gasLimit == 0 {
-		estimatedGas, err := c.EstimateGas(types.CallParams{
-			From:     params.Account.Address,
-			To:       params.TxData.To,
-			Gas:      params.TxData.Gas,
-			GasPrice: gasFeeCap,
+	var txInner ethTypes.TxData
+	switch txType {
+	case types.TxTypeLegacy:
+		txInner = &ethTypes.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gasLimit,
+			To:       &toAddress,
 			Value:    params.TxData.Value,
 			Data:     params.TxData.Data,
-		})
+		}
+	case types.TxTypeEip2930:
+		txInner = &ethTypes.AccessListTx{
+			ChainID:    params.TxData.ChainId,
+			Nonce:      nonce,
+			GasPrice:   gasPrice,
+			Gas:        gasLimit,
+			To:         &toAddress,
+			Value:      params.TxData.Value,
+			Data:       params.TxData.Data,
+			AccessList: accessList,
+		}
+	case types.TxTypeEip4844:
+		blobTx, err := c.buildBlobTx(ctx, params.TxData, nonce, gasLimit, gasTipCap, gasFeeCap, accessList)
 		if err != nil {
 			return nil, err
 		}
-		gasLimit = estimatedGas.Uint64()
+		txInner = blobTx
+	default:
+		txInner = &ethTypes.DynamicFeeTx{
+			ChainID:    params.TxData.ChainId,
+			Nonce:      nonce,
+			Gas:        gasLimit,
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			To:         &toAddress,
+			Value:      params.TxData.Value,
+			Data:       params.TxData.Data,
+			AccessList: accessList,
+		}
 	}
+	return ethTypes.NewTx(txInner), nil
+}
 
-	dynamicFeeTx := ethTypes.DynamicFeeTx{
-		ChainID:   params.TxData.ChainId,
-		Nonce:     nonce,
-		Gas:       gasLimit,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		To:        &toAddress,
-		Value:     params.TxData.Value,
-		Data:      params.TxData.Data,
+func (c *RpcClient) resolveNonce(ctx context.Context, params types.TxInteractionParams, reservedNonce *uint64) (uint64, error) {
+	if reservedNonce != nil {
+		return *reservedNonce, nil
+	}
+	if params.TxData.Nonce != nil {
+		return *params.TxData.Nonce, nil
 	}
-	tx := ethTypes.NewTx(&dynamicFeeTx)
 
-	if params.Account != nil {
-		chainId := big.NewInt(c.chain.Id)
-		signedTx, err := ethTypes.SignTx(tx, ethTypes.LatestSignerForChainID(chainId), params.Account.PrivateKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %v", err)
-		}
-		tx = signedTx
+	var from string
+	if params.Signer != nil {
+		from = params.Signer.Address()
 	}
+	return c.getTransactionCount(ctx, from, "pending")
+}
 
-	return tx, nil
+func isSetCodeTx(txData *types.TxData) bool {
+	return txData.AuthorizationList != nil || txData.SelfAuthorizations != nil || txData.Type == types.TxTypeEip7702
 }
 
-func (c *RpcClient) SimulateTx(params types.TxInteractionParams) (*types.SimulateTxResult, error) {
-	tx, err := c.PrepareTxRequest(types.TxInteractionParams{
-		TxData:  params.TxData,
-		Account: params.Account,
-	})
+// resolveTxType picks the transaction type from the fee fields, falling back
+// to whether the latest block carries a base fee.
+func (c *RpcClient) resolveTxType(ctx context.Context, txData *types.TxData) (types.TxType, error) {
+	switch txData.Type {
+	case types.TxTypeLegacy, types.TxTypeEip2930, types.TxTypeEip1559, types.TxTypeEip4844, types.TxTypeEip7702:
+		return txData.Type, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unsupported transaction type: %s", txData.Type)
+	}
+
+	if txData.AuthorizationList != nil || txData.SelfAuthorizations != nil {
+		return types.TxTypeEip7702, nil
+	}
+	if txData.BlobData != nil {
+		return types.TxTypeEip4844, nil
+	}
+	if txData.MaxFeePerGas != nil || txData.MaxPriorityFeePerGas != nil {
+		return types.TxTypeEip1559, nil
+	}
+
+	legacyType := types.TxTypeLegacy
+	if txData.AccessList != nil {
+		legacyType = types.TxTypeEip2930
+	}
+	if txData.GasPrice != nil {
+		return legacyType, nil
+	}
+
+	supportsEip1559, err := c.supportsEip1559(ctx)
+	if err != nil {
+		return "", err
+	}
+	if supportsEip1559 {
+		return types.TxTypeEip1559, nil
+	}
+	return legacyType, nil
+}
+
+func (c *RpcClient) supportsEip1559(ctx context.Context) (bool, error) {
+	result, err := c.Call(ctx, "eth_getBlockByNumber", []interface{}{"latest", false})
+	if err != nil {
+		return false, err
+	}
+
+	var header struct {
+		BaseFeePerGas *string `json:"baseFeePerGas"`
+	}
+	if err := json.Unmarshal(result, &header); err != nil {
+		return false, fmt.Errorf("failed to unmarshal block: %v", err)
+	}
+
+	return header.BaseFeePerGas != nil, nil
+}
+
+func (c *RpcClient) CreateAccessList(ctx context.Context, params types.CallParams) (*types.AccessListResult, error) {
+	result, err := c.Call(ctx, "eth_createAccessList", []interface{}{params, "latest"})
 	if err != nil {
 		return nil, err
 	}
 
+	var rawResult types.RawAccessListResult
+	if err := json.Unmarshal(result, &rawResult); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal access list: %v", err)
+	}
+	if rawResult.Error != "" {
+		return nil, fmt.Errorf("failed to create access list: %s", rawResult.Error)
+	}
+
+	accessList := rawResult.AccessList
+	if accessList == nil {
+		accessList = types.AccessList{}
+	}
+	return &types.AccessListResult{
+		AccessList: accessList,
+		GasUsed:    HexToBigInt(rawResult.GasUsed),
+	}, nil
+}
+
+// withAccessList returns a copy of txData carrying the generated access list
+// and its gas estimate, or txData itself when the list does not lower gas.
+func (c *RpcClient) withAccessList(ctx context.Context, from string, txData *types.TxData) (*types.TxData, error) {
+	callParams := types.CallParams{
+		From:  from,
+		To:    txData.To,
+		Value: txData.Value,
+		Data:  txData.Data,
+	}
+
+	accessListResult, err := c.CreateAccessList(ctx, callParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(accessListResult.AccessList) == 0 {
+		return txData, nil
+	}
+
+	plainGas, err := c.EstimateGas(ctx, callParams)
+	if err != nil {
+		return nil, err
+	}
+	callParams.AccessList = accessListResult.AccessList
+	accessListGas, err := c.EstimateGas(ctx, callParams)
+	if err != nil {
+		return nil, err
+	}
+	if accessListGas.Cmp(plainGas) >= 0 {
+		return txData, nil
+	}
+
+	withList := *txData
+	withList.AccessList = accessListResult.AccessList
+	if withList.Gas == nil {
+		gas := accessListGas.Uint64()
+		withList.Gas = &gas
+	}
+	return &withList, nil
+}
+
+func toEthAccessList(accessList types.AccessList) ethTypes.AccessList {
+	if accessList == nil {
+		return nil
+	}
+
+	ethAccessList := make(ethTypes.AccessList, 0, len(accessList))
+	for _, item := range accessList {
+		storageKeys := make([]common.Hash, 0, len(item.StorageKeys))
+		for _, key := range item.StorageKeys {
+			storageKeys = append(storageKeys, common.HexToHash(key))
+		}
+		ethAccessList = append(ethAccessList, ethTypes.AccessTuple{
+			Address:     common.HexToAddress(item.Address),
+			StorageKeys: storageKeys,
+		})
+	}
+	return ethAccessList
+}
+
+func fromEthAccessList(ethAccessList ethTypes.AccessList) types.AccessList {
+	if ethAccessList == nil {
+		return nil
+	}
+
+	accessList := make(types.AccessList, 0, len(ethAccessList))
+	for _, tuple := range ethAccessList {
+		storageKeys := make([]string, 0, len(tuple.StorageKeys))
+		for _, key := range tuple.StorageKeys {
+			storageKeys = append(storageKeys, key.Hex())
+		}
+		accessList = append(accessList, types.AccessListItem{
+			Address:     tuple.Address.Hex(),
+			StorageKeys: storageKeys,
+		})
+	}
+	return accessList
+}
+
+func (c *RpcClient) SimulateTx(ctx context.Context, params types.TxInteractionParams) (*types.SimulateTxResult, error) {
+	return c.simulateTx(ctx, params, nil)
+}
+func (c *RpcClient) simulateTx(ctx context.Context, params types.TxInteractionParams, parsedABI *abi.ABI) (*types.SimulateTxResult, error) {
+	params.Signer = resolveSigner(params.Signer, params.Account)
+	var tx *ethTypes.Transaction
+	if isSetCodeTx(params.TxData) {
+		txData, err := c.withSelfAuthorizations(ctx, params, nil)
+		if err != nil {
+			return nil, err
+		}
+		params.TxData = txData
+		// go-ethereum has no set-code transaction type here, so the result
+		// carries an unsigned stand-in instead of the transaction SendTx sends.
+		tx, err = c.prepareUnsignedTx(ctx, params, nil)
+		if err != nil {
+			return nil, decodeRevert(err, parsedABI)
+		}
+	} else {
+		var err error
+		tx, err = c.prepareTx(ctx, params, nil)
+		if err != nil {
+			return nil, decodeRevert(err, parsedABI)
+		}
+	}
+
 	callParams := types.CallParams{
-		To:       params.TxData.To,
-		Value:    params.TxData.Value,
-		Data:     params.TxData.Data,
-		Gas:      params.TxData.Gas,
-		GasPrice: params.TxData.MaxFeePerGas,
+		To:                params.TxData.To,
+		Value:             params.TxData.Value,
+		Data:              params.TxData.Data,
+		Gas:               tx.Gas(),
+		GasPrice:          tx.GasPrice(),
+		AccessList:        fromEthAccessList(tx.AccessList()),
+		AuthorizationList: params.TxData.AuthorizationList,
 	}
 	callParamsJson, err := json.Marshal(callParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal callParams: %v", err)
 	}
 
-	result, err := c.Call("eth_call", []interface{}{json.RawMessage(callParamsJson), "latest"})
+	result, err := c.Call(ctx, "eth_call", []interface{}{json.RawMessage(callParamsJson), "latest"})
 	if err != nil {
-		return nil, fmt.Errorf("simulation failed: %w", err)
+		return nil, fmt.Errorf("simulation failed: %w", decodeRevert(err, parsedABI))
 	}
 
 	var simulationResult string
@@ -320,22 +659,72 @@ func (c *RpcClient) SimulateTx(params types.TxInteractionParams) (*types.Simulat
 		Result: simulationResult,
 	}, nil
 }
-func (c *RpcClient) SendTx(params types.TxInteractionParams) (string, error) {
-	tx, err := c.PrepareTxRequest(types.TxInteractionParams{
-		TxData:  params.TxData,
-		Account: params.Account,
-	})
-	if err != nil {
-		return "", err
+func (c *RpcClient) SendTx(ctx context.Context, params types.TxInteractionParams) (string, error) {
+	params.Signer = resolveSigner(params.Signer, params.Account)
+	useNonceManager := c.nonces != nil && params.Signer != nil && params.TxData.Nonce == nil
+	if !useNonceManager {
+		return c.sendTx(ctx, params, nil)
+	}
+
+	address := params.Signer.Address()
+	fetchPending := func(ctx context.Context) (uint64, error) {
+		return c.getTransactionCount(ctx, address, "pending")
+	}
+	// Self authorizations take the nonces right after the transaction's own.
+	count := uint64(1 + len(params.TxData.SelfAuthorizations))
+	confirm := func(nonce uint64) {
+		for i := uint64(0); i < count; i++ {
+			c.nonces.Confirm(c.chain.Id, address, nonce+i)
+		}
+	}
+	release := func(nonce uint64) {
+		for i := count; i > 0; i-- {
+			c.nonces.Release(c.chain.Id, address, nonce+i-1)
+		}
 	}
+	for attempt := 0; ; attempt++ {
+		nonce, err := c.nonces.NextRange(ctx, c.chain.Id, address, count, fetchPending)
+		if err != nil {
+			return "", err
+		}
 
-	data, err := tx.MarshalBinary()
+		txHash, err := c.sendTx(ctx, params, &nonce)
+		if err == nil {
+			confirm(nonce)
+			return txHash, nil
+		}
+
+		// The local nonce drifted from the node's, e.g. after a send from
+		// elsewhere or a dropped transaction; resync and try again.
+		if attempt < 2 && errors.Is(err, types.ErrNonceTooLow) {
+			confirm(nonce)
+			continue
+		}
+		if attempt < 2 && errors.Is(err, types.ErrNonceTooHigh) {
+			release(nonce)
+			if err := c.nonces.Resync(ctx, c.chain.Id, address, fetchPending); err != nil {
+				return "", err
+			}
+			continue
+		}
+		// If the transaction reached the node after all, the next sync
+		// from the pending nonce discards the released ones.
+		release(nonce)
+		return "", err
+	}
+}
+func (c *RpcClient) sendTx(ctx context.Context, params types.TxInteractionParams, reservedNonce *uint64) (string, error) {
+	data, hash, err := c.signRawTx(ctx, params, reservedNonce)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal signed transaction: %w", err)
+		return "", err
 	}
 
-	result, err := c.Call("eth_sendRawTransaction", []interface{}{hexutil.Encode(data)})
+	result, err := c.Call(ctx, "eth_sendRawTransaction", []interface{}{hexutil.Encode(data)})
 	if err != nil {
+		// A retried or rebroadcast transaction is already in the node's pool.
+		if isAlreadyKnownError(err) {
+			return hash.Hex(), nil
+		}
 		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
 
@@ -347,7 +736,7 @@ func (c *RpcClient) SendTx(params types.TxInteractionParams) (string, error) {
 	return txHash, nil
 }
 
-func (c *RpcClient) ReadContract(params types.ReadContractParams) ([]byte, error) {
+func (c *RpcClient) ReadContract(ctx context.Context, params types.ReadContractParams) ([]byte, error) {
 	parsedABI, err := abi.JSON(strings.NewReader(params.Abi))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ABI: %v", err)
@@ -364,9 +753,9 @@ func (c *RpcClient) ReadContract(params types.ReadContractParams) ([]byte, error
 		"data": "0x" + hex.EncodeToString(data),
 	}
 
-	result, err := c.Call("eth_call", []interface{}{payload, "latest"})
+	result, err := c.Call(ctx, "eth_call", []interface{}{payload, "latest"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %v", err)
+		return nil, fmt.Errorf("failed to call contract: %w", decodeRevert(err, &parsedABI))
 	}
 
 	var calldata string
@@ -377,7 +766,7 @@ func (c *RpcClient) ReadContract(params types.ReadContractParams) ([]byte, error
 	var output interface{}
 	err = parsedABI.UnpackIntoInterface(&output, params.FunctionName, common.FromHex(calldata))
 	if err != nil {
-		return nil, fmt.Errorf("failed to unpack result: %v", err)
+		return nil, fmt.Errorf("%w: %v", types.ErrUnpackResult, err)
 	}
 
 	outputBytes, err := json.Marshal(output)
@@ -387,9 +776,9 @@ func (c *RpcClient) ReadContract(params types.ReadContractParams) ([]byte, error
 
 	return outputBytes, nil
 }
-func (c *RpcClient) WriteContract(params types.ContractInteractionParams) (string, error) {
-	if params.Account == nil {
-		return "", fmt.Errorf("account with private key is required to sign the transaction")
+func (c *RpcClient) WriteContract(ctx context.Context, params types.ContractInteractionParams) (string, error) {
+	if params.Account == nil && params.Signer == nil {
+		return "", fmt.Errorf("a signer or an account with private key is required to sign the transaction")
 	}
 
 	parsedABI, err := abi.JSON(strings.NewReader(params.Abi))
@@ -405,23 +794,30 @@ func (c *RpcClient) WriteContract(params types.ContractInteractionParams) (strin
 	toAddress := common.HexToAddress(params.Address)
 
 	txData := &types.TxData{
-		To:    toAddress.Hex(),
-		Value: params.Value,
-		Nonce: params.Nonce,
-		Data:  data,
-	}
-
-	txHash, err := c.SendTx(types.TxInteractionParams{
-		TxData:  txData,
-		Account: params.Account,
+		To:                   toAddress.Hex(),
+		Value:                params.Value,
+		Nonce:                params.Nonce,
+		Gas:                  params.GasLimit,
+		MaxFeePerGas:         params.MaxFeePerGas,
+		MaxPriorityFeePerGas: params.MaxPriorityFeePerGas,
+		Data:                 data,
+		AuthorizationList:    params.AuthorizationList,
+		SelfAuthorizations:   params.SelfAuthorizations,
+	}
+
+	txHash, err := c.SendTx(ctx, types.TxInteractionParams{
+		TxData:         txData,
+		Account:        params.Account,
+		Signer:         params.Signer,
+		AutoAccessList: params.AutoAccessList,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %v", err)
+		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	return txHash, nil
 }
-func (c *RpcClient) SimulateContract(params types.ContractInteractionParams) (*types.SimulateTxResult, error) {
+func (c *RpcClient) SimulateContract(ctx context.Context, params types.ContractInteractionParams) (*types.SimulateTxResult, error) {
 	parsedABI, err := abi.JSON(strings.NewReader(params.Abi))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ABI: %v", err)
@@ -435,17 +831,25 @@ func (c *RpcClient) SimulateContract(params types.ContractInteractionParams) (*t
 	toAddress := common.HexToAddress(params.Address)
 
 	txData := &types.TxData{
-		To:    toAddress.Hex(),
-		Value: params.Value,
-		Data:  data,
-	}
-
-	simulationResult, err := c.SimulateTx(types.TxInteractionParams{
-		TxData:  txData,
-		Account: params.Account,
-	})
+		To:                   toAddress.Hex(),
+		Value:                params.Value,
+		Nonce:                params.Nonce,
+		Gas:                  params.GasLimit,
+		MaxFeePerGas:         params.MaxFeePerGas,
+		MaxPriorityFeePerGas: params.MaxPriorityFeePerGas,
+		Data:                 data,
+		AuthorizationList:    params.AuthorizationList,
+		SelfAuthorizations:   params.SelfAuthorizations,
+	}
+
+	simulationResult, err := c.simulateTx(ctx, types.TxInteractionParams{
+		TxData:         txData,
+		Account:        params.Account,
+		Signer:         params.Signer,
+		AutoAccessList: params.AutoAccessList,
+	}, &parsedABI)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %v", err)
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	return simulationResult, nil
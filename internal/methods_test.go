@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// newTxStandIn answers the calls made while building a transaction, with
+// pending as the account's pending nonce, and eth_call with 0x.
+func newTxStandIn(t *testing.T, pending string) *RpcClient {
+	t.Helper()
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		switch request.Method {
+		case "eth_getTransactionCount":
+			return rpcResult(request, pending)
+		case "eth_getBlockByNumber":
+			return rpcResult(request, map[string]string{"number": "0x1", "baseFeePerGas": "0x1"})
+		case "eth_feeHistory":
+			return rpcResult(request, map[string]interface{}{"reward": [][]string{{"0x1", "0x1", "0x1"}}, "baseFeePerGas": []string{"0x1"}})
+		case "eth_estimateGas":
+			return rpcResult(request, "0x10000")
+		case "eth_call":
+			return rpcResult(request, "0x")
+		}
+		return rpcFailure(request, -32601, "unexpected call to "+request.Method)
+	})
+	return NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL, Chain: types.Chain{Id: 1}})
+}
+
+func TestSimulateTxReturnsSignedTx(t *testing.T) {
+	c := newTxStandIn(t, "0x5")
+	account := testAccount(t)
+
+	result, err := c.SimulateTx(context.Background(), types.TxInteractionParams{
+		Account: account,
+		TxData:  &types.TxData{To: "0x000000000000000000000000000000000000dEaD", Value: big.NewInt(1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := ethTypes.Sender(ethTypes.LatestSignerForChainID(big.NewInt(1)), result.Tx)
+	if err != nil {
+		t.Fatalf("simulated transaction is not signed: %v", err)
+	}
+	if sender.Hex() != account.Address {
+		t.Errorf("simulated transaction signed by %s, want %s", sender.Hex(), account.Address)
+	}
+	if result.Tx.Nonce() != 5 {
+		t.Errorf("got nonce %d, want 5", result.Tx.Nonce())
+	}
+}
+
+func TestSimulateTxSetCode(t *testing.T) {
+	c := newTxStandIn(t, "0x5")
+	account := testAccount(t)
+
+	result, err := c.SimulateTx(context.Background(), types.TxInteractionParams{
+		Account: account,
+		TxData: &types.TxData{
+			To:                 account.Address,
+			SelfAuthorizations: []types.Authorization{{Address: "0x00000000000000000000000000000000000000cc"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Tx.Type() != ethTypes.DynamicFeeTxType || result.Tx.Nonce() != 5 {
+		t.Errorf("got type %d nonce %d, want the dynamic fee stand-in with nonce 5", result.Tx.Type(), result.Tx.Nonce())
+	}
+}
+
+// failingSigner fails every signature with err.
+type failingSigner struct {
+	address string
+	err     error
+}
+
+func (s failingSigner) Address() string { return s.address }
+
+func (s failingSigner) SignTransaction(ctx context.Context, tx *ethTypes.Transaction, chainId *big.Int) (*ethTypes.Transaction, error) {
+	return nil, s.err
+}
+
+func (s failingSigner) SignHash(ctx context.Context, hash []byte) (string, error) { return "", s.err }
+
+func (s failingSigner) SignMessage(ctx context.Context, message []byte) (string, error) {
+	return "", s.err
+}
+
+func (s failingSigner) SignTypedData(ctx context.Context, typedData types.TypedData) (string, error) {
+	return "", s.err
+}
+
+func TestSignerErrorsKeepTheirType(t *testing.T) {
+	c := newTxStandIn(t, "0x5")
+	signerErr := &types.RpcError{Code: -32000, Message: "signer locked"}
+	signer := failingSigner{address: testAccount(t).Address, err: signerErr}
+
+	tests := map[string]*types.TxData{
+		"transaction": {To: "0x000000000000000000000000000000000000dEaD"},
+		"set-code transaction": {
+			To: "0x000000000000000000000000000000000000dEaD",
+			AuthorizationList: []types.SignedAuthorization{{
+				ChainId: big.NewInt(1),
+				Address: "0x00000000000000000000000000000000000000cc",
+				R:       big.NewInt(1),
+				S:       big.NewInt(1),
+			}},
+		},
+	}
+	for name, txData := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.SendTx(context.Background(), types.TxInteractionParams{Signer: signer, TxData: txData})
+			var rpcErr *types.RpcError
+			if !errors.As(err, &rpcErr) || rpcErr != signerErr {
+				t.Errorf("got error %v, want the signer's RpcError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type nonceState struct {
+	mu   sync.Mutex
+	next uint64
+	// released holds nonces below next that were reserved but never used,
+	// in ascending order; they are handed out again first.
+	released []uint64
+	// reserved holds nonces handed out whose send has not finished yet.
+	reserved map[uint64]struct{}
+}
+
+type NonceManager struct {
+	mu     sync.Mutex
+	states map[string]*nonceState
+}
+
+func NewNonceManager() *NonceManager {
+	return &NonceManager{states: make(map[string]*nonceState)}
+}
+
+func (m *NonceManager) state(chainId int64, address string) *nonceState {
+	key := fmt.Sprintf("%d:%s", chainId, strings.ToLower(address))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.states[key]
+	if !ok {
+		state = &nonceState{reserved: make(map[uint64]struct{})}
+		m.states[key] = state
+	}
+
+	return state
+}
+
+func (m *NonceManager) Next(ctx context.Context, chainId int64, address string, fetch func(ctx context.Context) (uint64, error)) (uint64, error) {
+	return m.NextRange(ctx, chainId, address, 1, fetch)
+}
+
+func (m *NonceManager) NextRange(ctx context.Context, chainId int64, address string, count uint64, fetch func(ctx context.Context) (uint64, error)) (uint64, error) {
+	if count == 0 {
+		return 0, fmt.Errorf("cannot reserve zero nonces")
+	}
+
+	state := m.state(chainId, address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	pending, err := fetch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Released nonces the node has seen since were used after all.
+	for len(state.released) > 0 && state.released[0] < pending {
+		state.released = state.released[1:]
+	}
+	if pending > state.next {
+		state.next = pending
+	}
+
+	start, ok := state.takeReleased(count)
+	if !ok {
+		start = state.next
+		state.next += count
+	}
+	for nonce := start; nonce < start+count; nonce++ {
+		state.reserved[nonce] = struct{}{}
+	}
+
+	return start, nil
+}
+
+// takeReleased hands out count consecutive released nonces, or a run of
+// released nonces that ends at next and is extended from there.
+func (s *nonceState) takeReleased(count uint64) (uint64, bool) {
+	for i, start := range s.released {
+		end := i
+		for end < len(s.released) && s.released[end] == start+uint64(end-i) {
+			end++
+		}
+		run := uint64(end - i)
+		switch {
+		case run >= count:
+			s.released = append(s.released[:i], s.released[i+int(count):]...)
+		case start+run == s.next:
+			s.released = append(s.released[:i], s.released[end:]...)
+			s.next = start + count
+		default:
+			continue
+		}
+		return start, true
+	}
+	return 0, false
+}
+
+func (m *NonceManager) Release(chainId int64, address string, nonce uint64) {
+	state := m.state(chainId, address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	delete(state.reserved, nonce)
+	if nonce >= state.next {
+		return
+	}
+	if nonce+1 == state.next {
+		state.next = nonce
+		return
+	}
+
+	index := sort.Search(len(state.released), func(i int) bool { return state.released[i] >= nonce })
+	if index < len(state.released) && state.released[index] == nonce {
+		return
+	}
+	state.released = append(state.released, 0)
+	copy(state.released[index+1:], state.released[index:])
+	state.released[index] = nonce
+}
+
+func (m *NonceManager) Confirm(chainId int64, address string, nonce uint64) {
+	state := m.state(chainId, address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	delete(state.reserved, nonce)
+}
+
+func (m *NonceManager) Resync(ctx context.Context, chainId int64, address string, fetch func(ctx context.Context) (uint64, error)) error {
+	state := m.state(chainId, address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	pending, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if pending >= state.next {
+		state.next = pending
+		state.released = nil
+		return nil
+	}
+
+	// Every nonce the node is missing is free again, except those that
+	// concurrent sends are still using.
+	state.released = nil
+	for nonce := pending; nonce < state.next; nonce++ {
+		if _, ok := state.reserved[nonce]; !ok {
+			state.released = append(state.released, nonce)
+		}
+	}
+	for len(state.released) > 0 && state.released[len(state.released)-1] == state.next-1 {
+		state.released = state.released[:len(state.released)-1]
+		state.next--
+	}
+
+	return nil
+}
+
+func (m *NonceManager) Reset(chainId int64, address string) {
+	state := m.state(chainId, address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.next = 0
+	state.released = nil
+	state.reserved = make(map[uint64]struct{})
+}
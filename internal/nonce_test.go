@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+const testNonceAddress = "0x00000000000000000000000000000000000000aa"
+
+func pendingNonce(pending uint64) func(ctx context.Context) (uint64, error) {
+	return func(ctx context.Context) (uint64, error) {
+		return pending, nil
+	}
+}
+
+func nextNonces(t *testing.T, m *NonceManager, pending uint64, count int) []uint64 {
+	t.Helper()
+	nonces := make([]uint64, count)
+	for i := range nonces {
+		nonce, err := m.Next(context.Background(), 1, testNonceAddress, pendingNonce(pending))
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonces[i] = nonce
+	}
+	return nonces
+}
+
+func TestNonceManagerConcurrentNext(t *testing.T) {
+	m := NewNonceManager()
+	var mu sync.Mutex
+	var nonces []int
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := m.Next(context.Background(), 1, testNonceAddress, pendingNonce(3))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			nonces = append(nonces, int(nonce))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(nonces)
+	for i, nonce := range nonces {
+		if nonce != 3+i {
+			t.Fatalf("got nonces %v, want 3 to 52 without gaps or repeats", nonces)
+		}
+	}
+}
+
+func TestNonceManagerRelease(t *testing.T) {
+	m := NewNonceManager()
+	nextNonces(t, m, 5, 3)
+	m.Release(1, testNonceAddress, 6)
+
+	if got := nextNonces(t, m, 5, 2); fmt.Sprint(got) != "[6 8]" {
+		t.Errorf("got %v, want the released nonce first: [6 8]", got)
+	}
+}
+
+func TestNonceManagerSkipsNoncesUsedElsewhere(t *testing.T) {
+	m := NewNonceManager()
+	nextNonces(t, m, 5, 2)
+	m.Release(1, testNonceAddress, 5)
+
+	// Another wallet sent nonces 5 to 9 in the meantime.
+	if got := nextNonces(t, m, 10, 1); fmt.Sprint(got) != "[10]" {
+		t.Errorf("got %v, want [10]", got)
+	}
+}
+
+func TestNonceManagerResyncKeepsReservations(t *testing.T) {
+	m := NewNonceManager()
+	nextNonces(t, m, 5, 3)
+
+	// Nonces 5 and 7 are still being sent when the node rejects 6 as too
+	// high because 5 has not reached it yet.
+	m.Release(1, testNonceAddress, 6)
+	if err := m.Resync(context.Background(), 1, testNonceAddress, pendingNonce(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := nextNonces(t, m, 5, 2); fmt.Sprint(got) != "[6 8]" {
+		t.Errorf("got %v, want [6 8] without reissuing the reserved 5 and 7", got)
+	}
+}
+
+func TestNonceManagerResyncLowersNext(t *testing.T) {
+	m := NewNonceManager()
+	for _, nonce := range nextNonces(t, m, 5, 3) {
+		m.Confirm(1, testNonceAddress, nonce)
+	}
+
+	// The node dropped every transaction that was sent.
+	if err := m.Resync(context.Background(), 1, testNonceAddress, pendingNonce(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := nextNonces(t, m, 5, 2); fmt.Sprint(got) != "[5 6]" {
+		t.Errorf("got %v, want [5 6]", got)
+	}
+}
+
+func TestNonceManagerNextRange(t *testing.T) {
+	m := NewNonceManager()
+	nextRange := func(count uint64) uint64 {
+		t.Helper()
+		nonce, err := m.NextRange(context.Background(), 1, testNonceAddress, count, pendingNonce(5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return nonce
+	}
+
+	if got := nextRange(3); got != 5 {
+		t.Fatalf("got range at %d, want 5", got)
+	}
+	if got := nextNonces(t, m, 5, 1); fmt.Sprint(got) != "[8]" {
+		t.Errorf("got %v after the range 5 to 7, want [8]", got)
+	}
+
+	// 6 alone is too short for a range of two, so the range goes after 8.
+	m.Release(1, testNonceAddress, 6)
+	if got := nextRange(2); got != 9 {
+		t.Errorf("got range at %d, want 9", got)
+	}
+
+	// 6 and 7 together fit a range of two.
+	m.Release(1, testNonceAddress, 7)
+	if got := nextRange(2); got != 6 {
+		t.Errorf("got range at %d, want 6", got)
+	}
+
+	if _, err := m.NextRange(context.Background(), 1, testNonceAddress, 0, pendingNonce(5)); err == nil {
+		t.Error("expected an error for an empty range")
+	}
+}
+
+func TestNonceManagerNextRangeExtendsReleasedRun(t *testing.T) {
+	m := NewNonceManager()
+	nextNonces(t, m, 5, 3)
+	// 6 is given back first, then 7, the last one handed out, lowers next.
+	m.Release(1, testNonceAddress, 6)
+	m.Release(1, testNonceAddress, 7)
+
+	nonce, err := m.NextRange(context.Background(), 1, testNonceAddress, 3, pendingNonce(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 6 {
+		t.Errorf("got range at %d, want 6", nonce)
+	}
+	if got := nextNonces(t, m, 5, 1); fmt.Sprint(got) != "[9]" {
+		t.Errorf("got %v after the range 6 to 8, want [9]", got)
+	}
+}
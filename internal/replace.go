@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const defaultFeeBumpPercent = 10
+
+// SpeedUpTransaction re-sends a pending transaction with the same nonce and
+// payload and higher fees.
+func (c *RpcClient) SpeedUpTransaction(ctx context.Context, params types.ReplaceTransactionParams) (string, error) {
+	return c.replaceTransaction(ctx, params, false)
+}
+
+// CancelTransaction replaces a pending transaction with a zero-value transfer
+// to its sender.
+func (c *RpcClient) CancelTransaction(ctx context.Context, params types.ReplaceTransactionParams) (string, error) {
+	return c.replaceTransaction(ctx, params, true)
+}
+
+func (c *RpcClient) replaceTransaction(ctx context.Context, params types.ReplaceTransactionParams, cancel bool) (string, error) {
+	signer := resolveSigner(params.Signer, params.Account)
+	if signer == nil {
+		return "", fmt.Errorf("replacing a transaction requires a signer")
+	}
+
+	tx, err := c.GetTransaction(ctx, params.Hash)
+	if err != nil {
+		return "", err
+	}
+	if tx.BlockNumber != nil {
+		return "", fmt.Errorf("transaction %s is already mined", params.Hash)
+	}
+	if common.HexToAddress(tx.From) != common.HexToAddress(signer.Address()) {
+		return "", fmt.Errorf("transaction %s was sent by %s, not by the account", params.Hash, tx.From)
+	}
+
+	txType, err := replacementTxType(tx.Type, cancel)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := HexToUint64(tx.Nonce)
+	txData := &types.TxData{
+		Type:    txType,
+		ChainId: tx.ChainId,
+		Nonce:   &nonce,
+	}
+	if cancel {
+		txData.To = signer.Address()
+		txData.Value = new(big.Int)
+	} else {
+		// Transactions without a recipient deploy a contract, which SendTx
+		// cannot express; a replacement would call the zero address instead.
+		if tx.To == "" {
+			return "", fmt.Errorf("transaction %s creates a contract and cannot be sped up", params.Hash)
+		}
+		data, err := hexutil.Decode(tx.Input)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode transaction input: %v", err)
+		}
+		gas := tx.Gas
+		txData.To = tx.To
+		txData.Value = tx.Value
+		txData.Data = data
+		txData.Gas = &gas
+		txData.AccessList = tx.AccessList
+		txData.AuthorizationList = tx.AuthorizationList
+	}
+	if txType == types.TxTypeEip2930 && txData.AccessList == nil {
+		txData.AccessList = types.AccessList{}
+	}
+
+	bumpPercent := params.FeeBumpPercent
+	if bumpPercent == 0 {
+		bumpPercent = defaultFeeBumpPercent
+	}
+	if err := c.bumpFees(ctx, tx, txData, bumpPercent); err != nil {
+		return "", err
+	}
+
+	return c.SendTx(ctx, types.TxInteractionParams{
+		TxData: txData,
+		Signer: signer,
+	})
+}
+
+func replacementTxType(txType uint64, cancel bool) (types.TxType, error) {
+	switch txType {
+	case ethTypes.LegacyTxType:
+		return types.TxTypeLegacy, nil
+	case ethTypes.AccessListTxType:
+		return types.TxTypeEip2930, nil
+	case ethTypes.DynamicFeeTxType:
+		return types.TxTypeEip1559, nil
+	case setCodeTxType:
+		// A cancellation does not need to repeat the delegation.
+		if cancel {
+			return types.TxTypeEip1559, nil
+		}
+		return types.TxTypeEip7702, nil
+	case ethTypes.BlobTxType:
+		return "", fmt.Errorf("blob transactions cannot be replaced because nodes do not return their blobs")
+	default:
+		return "", fmt.Errorf("unsupported transaction type: %d", txType)
+	}
+}
+
+// bumpFees sets the fees of the replacement to the original ones raised by
+// bumpPercent, or to the current network fees when those are higher.
+func (c *RpcClient) bumpFees(ctx context.Context, tx *types.Transaction, txData *types.TxData, bumpPercent uint64) error {
+	if txData.Type == types.TxTypeLegacy || txData.Type == types.TxTypeEip2930 {
+		gasPrice, err := c.GetGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		txData.GasPrice = maxBigInt(bumpFee(tx.GasPrice, bumpPercent), gasPrice)
+		return nil
+	}
+
+	maxFeePerGas, maxPriorityFeePerGas, err := c.resolveFees(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	txData.MaxPriorityFeePerGas = maxBigInt(bumpFee(tx.MaxPriorityFeePerGas, bumpPercent), maxPriorityFeePerGas)
+	txData.MaxFeePerGas = maxBigInt(bumpFee(tx.MaxFeePerGas, bumpPercent), maxFeePerGas, txData.MaxPriorityFeePerGas)
+	return nil
+}
+
+// bumpFee raises fee by percent, rounding up so that the increase is never
+// below it.
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	if fee == nil {
+		return new(big.Int)
+	}
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func maxBigInt(values ...*big.Int) *big.Int {
+	var result *big.Int
+	for _, value := range values {
+		if value != nil && (result == nil || value.Cmp(result) > 0) {
+			result = value
+		}
+	}
+	return new(big.Int).Set(result)
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+func TestSpeedUpRefusesContractCreation(t *testing.T) {
+	account := testAccount(t)
+	var sent bool
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		if request.Method != "eth_getTransactionByHash" {
+			sent = true
+			return rpcFailure(request, -32601, "unexpected call to "+request.Method)
+		}
+		return rpcResult(request, map[string]interface{}{
+			"hash":                 "0x01",
+			"blockNumber":          nil,
+			"from":                 account.Address,
+			"to":                   nil,
+			"value":                "0x0",
+			"gas":                  "0x5208",
+			"maxFeePerGas":         "0x3b9aca00",
+			"maxPriorityFeePerGas": "0x3b9aca00",
+			"nonce":                "0x3",
+			"input":                "0x6080",
+			"type":                 "0x2",
+			"chainId":              "0x1",
+		})
+	})
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+
+	_, err := c.SpeedUpTransaction(context.Background(), types.ReplaceTransactionParams{Hash: "0x01", Account: account})
+	if err == nil || !strings.Contains(err.Error(), "creates a contract") {
+		t.Fatalf("got error %v, want the contract creation to be refused", err)
+	}
+	if sent {
+		t.Error("a replacement was sent for a contract creation")
+	}
+}
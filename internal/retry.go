@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+var defaultRetryableRpcCodes = []int{-32005, -32016, -32603, 429}
+
+var retryableRpcMessages = []string{
+	"header not found",
+	"missing trie node",
+	"rate limit",
+	"too many requests",
+	"request timed out",
+	"service unavailable",
+}
+
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	multiplier     float64
+	jitter         float64
+	retryableCodes []int
+}
+
+func newRetryPolicy(policy *types.RetryPolicy) *retryPolicy {
+	if policy == nil {
+		return &retryPolicy{maxAttempts: 1}
+	}
+
+	p := &retryPolicy{
+		maxAttempts:    policy.MaxAttempts,
+		initialBackoff: policy.InitialBackoff,
+		maxBackoff:     policy.MaxBackoff,
+		multiplier:     policy.Multiplier,
+		jitter:         policy.Jitter,
+		retryableCodes: policy.RetryableCodes,
+	}
+	if p.maxAttempts <= 0 {
+		p.maxAttempts = 3
+	}
+	if p.initialBackoff <= 0 {
+		p.initialBackoff = 150 * time.Millisecond
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = 5 * time.Second
+	}
+	if p.multiplier < 1 {
+		p.multiplier = 2
+	}
+	if p.retryableCodes == nil {
+		p.retryableCodes = defaultRetryableRpcCodes
+	}
+
+	return p
+}
+
+func (p *retryPolicy) backoff(attempt int, err error) time.Duration {
+	delay := float64(p.initialBackoff) * math.Pow(p.multiplier, float64(attempt-1))
+	if delay > float64(p.maxBackoff) {
+		delay = float64(p.maxBackoff)
+	}
+	if p.jitter > 0 {
+		delay -= delay * p.jitter * rand.Float64()
+	}
+
+	var httpErr *types.HttpStatusError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > time.Duration(delay) {
+		return httpErr.RetryAfter
+	}
+
+	return time.Duration(delay)
+}
+
+// do runs attempt until it succeeds, returns a non-retryable error, or the
+// policy runs out of attempts.
+func (p *retryPolicy) do(ctx context.Context, attempt func() (retryable bool, err error)) error {
+	for i := 1; ; i++ {
+		retryable, err := attempt()
+		if err == nil || !retryable || i >= p.maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(p.backoff(i, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func isRetryableTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *types.HttpStatusError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	// The HTTP client wraps every failure in a *url.Error, which is a
+	// net.Error itself; look at the cause instead.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// The connection was dropped mid-response.
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var closeErr *websocket.CloseError
+	return errors.As(err, &closeErr)
+}
+
+func isAlreadyKnownError(err error) bool {
+	var rpcErr *types.RpcError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	message := strings.ToLower(rpcErr.Message)
+	return strings.Contains(message, "already known") || strings.Contains(message, "known transaction")
+}
+
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isRetryableRpcError(rpcError *types.RpcError, codes []int) bool {
+	for _, code := range codes {
+		if rpcError.Code == code {
+			return true
+		}
+	}
+
+	message := strings.ToLower(rpcError.Message)
+	for _, fragment := range retryableRpcMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
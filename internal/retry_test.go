@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+var fastRetry = &types.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+// newFlakyServer fails the first failures requests with fail and answers
+// the rest with echoHandler.
+func newFlakyServer(t *testing.T, failures int32, fail func(w http.ResponseWriter)) (*atomic.Int32, *httptest.Server) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= failures {
+			fail(w)
+			return
+		}
+		var request types.RpcRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		json.NewEncoder(w).Encode(echoHandler(request))
+	}))
+	t.Cleanup(server.Close)
+	return &hits, server
+}
+
+func TestRetryHttpStatus(t *testing.T) {
+	hits, server := newFlakyServer(t, 2, func(w http.ResponseWriter) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL, Retry: fastRetry})
+
+	if _, err := c.Call(context.Background(), "test_echo", []interface{}{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("got %d attempts, want 3", hits.Load())
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	hits, server := newFlakyServer(t, 10, func(w http.ResponseWriter) {
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	})
+	c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL, Retry: fastRetry})
+
+	_, err := c.Call(context.Background(), "test_echo", []interface{}{"a"})
+	var httpErr *types.HttpStatusError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("got error %v, want the last 429", err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("got %d attempts, want 3", hits.Load())
+	}
+}
+
+func TestRetrySkipsPermanentFailures(t *testing.T) {
+	tests := map[string]func(w http.ResponseWriter){
+		"client error": func(w http.ResponseWriter) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+		},
+		"invalid json": func(w http.ResponseWriter) {
+			w.Write([]byte("<html>not json</html>"))
+		},
+	}
+	for name, fail := range tests {
+		t.Run(name, func(t *testing.T) {
+			hits, server := newFlakyServer(t, 10, fail)
+			c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL, Retry: fastRetry})
+
+			if _, err := c.Call(context.Background(), "test_echo", []interface{}{"a"}); err == nil {
+				t.Fatal("expected an error")
+			}
+			if hits.Load() != 1 {
+				t.Errorf("got %d attempts, want 1", hits.Load())
+			}
+		})
+	}
+}
+
+func TestRetryRpcErrors(t *testing.T) {
+	tests := []struct {
+		code     int
+		message  string
+		attempts int32
+	}{
+		{-32005, "limit exceeded", 3},
+		{-32000, "header not found", 3},
+		{3, "execution reverted", 1},
+		{-32000, "nonce too low", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			var hits atomic.Int32
+			server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+				hits.Add(1)
+				return rpcFailure(request, test.code, test.message)
+			})
+			c := NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL, Retry: fastRetry})
+
+			_, err := c.Call(context.Background(), "test_echo", []interface{}{"a"})
+			var rpcErr *types.RpcError
+			if !errors.As(err, &rpcErr) || rpcErr.Code != test.code {
+				t.Fatalf("got error %v, want the node's error", err)
+			}
+			if hits.Load() != test.attempts {
+				t.Errorf("got %d attempts, want %d", hits.Load(), test.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	hits, server := newFlakyServer(t, 10, func(w http.ResponseWriter) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	c := NewRpcClient(types.NewRpcClientParams{
+		RpcUrl: server.URL,
+		Retry:  &types.RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.Call(ctx, "test_echo", []interface{}{"a"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retry kept waiting for %s after the context was done", elapsed)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("got %d attempts, want 1", hits.Load())
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := newRetryPolicy(&types.RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+	})
+	for attempt, want := range []time.Duration{100, 200, 300, 300} {
+		if got := policy.backoff(attempt+1, errors.New("failed")); got != want*time.Millisecond {
+			t.Errorf("attempt %d: got backoff %s, want %s", attempt+1, got, want*time.Millisecond)
+		}
+	}
+
+	retryAfter := &types.HttpStatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}
+	if got := policy.backoff(1, fmt.Errorf("wrapped: %w", retryAfter)); got != 2*time.Second {
+		t.Errorf("got backoff %s, want the server's Retry-After of 2s", got)
+	}
+
+	jittered := newRetryPolicy(&types.RetryPolicy{InitialBackoff: 100 * time.Millisecond, Jitter: 0.5})
+	for i := 0; i < 20; i++ {
+		if got := jittered.backoff(1, nil); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("got jittered backoff %s, want between 50ms and 100ms", got)
+		}
+	}
+}
+
+func TestIsRetryableTransportError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection refused", &url.Error{Op: "Post", URL: "http://node", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{"truncated body", fmt.Errorf("error decoding response: %w", io.ErrUnexpectedEOF), true},
+		{"connection closed", fmt.Errorf("error reading response: %w", io.EOF), true},
+		{"websocket closed", fmt.Errorf("error reading response: %w", &websocket.CloseError{Code: websocket.CloseGoingAway}), true},
+		{"server error", &types.HttpStatusError{StatusCode: http.StatusBadGateway}, true},
+		{"rate limited", &types.HttpStatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"client error", &types.HttpStatusError{StatusCode: http.StatusUnauthorized}, false},
+		{"invalid json", fmt.Errorf("error decoding response: %w", &json.SyntaxError{}), false},
+		{"unsupported scheme", &url.Error{Op: "Post", URL: "ftp://node", Err: errors.New("unsupported protocol scheme")}, false},
+		{"transport closed", errTransportClosed, false},
+		{"canceled", &url.Error{Op: "Post", URL: "http://node", Err: context.Canceled}, false},
+		{"deadline", context.DeadlineExceeded, false},
+	}
+	for _, test := range tests {
+		if got := isRetryableTransportError(test.err); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
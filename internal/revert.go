@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "conversion to an invalid enum value",
+	0x22: "access to an incorrectly encoded storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to a zero-initialized internal function",
+}
+
+// decodeRevert turns an execution reverted RPC error into a
+// ContractRevertError. Custom errors are looked up in parsedABI when given;
+// errors that carry no revert data are returned unchanged.
+func decodeRevert(err error, parsedABI *abi.ABI) error {
+	var rpcErr *types.RpcError
+	if !errors.As(err, &rpcErr) || !errors.Is(rpcErr, types.ErrExecutionReverted) {
+		return err
+	}
+
+	data := revertData(rpcErr.Data)
+	if len(data) < 4 {
+		return err
+	}
+
+	revertErr := &types.ContractRevertError{Data: data, Err: err}
+	selector, payload := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		reason, unpackErr := abi.UnpackRevert(data)
+		if unpackErr != nil {
+			return err
+		}
+		revertErr.Name = "Error"
+		revertErr.Signature = "Error(string)"
+		revertErr.Args = []interface{}{reason}
+		revertErr.Reason = reason
+	case bytes.Equal(selector, panicSelector):
+		if len(payload) < 32 {
+			return err
+		}
+		code := new(big.Int).SetBytes(payload[:32])
+		revertErr.Name = "Panic"
+		revertErr.Signature = "Panic(uint256)"
+		revertErr.Args = []interface{}{code}
+		revertErr.PanicCode = code
+		revertErr.Reason = fmt.Sprintf("panic: unknown panic code 0x%x", code)
+		if code.IsUint64() {
+			if reason, ok := panicReasons[code.Uint64()]; ok {
+				revertErr.Reason = fmt.Sprintf("panic: %s (0x%x)", reason, code)
+			}
+		}
+	default:
+		if parsedABI == nil {
+			return revertErr
+		}
+		for _, abiError := range parsedABI.Errors {
+			if !bytes.Equal(abiError.ID[:4], selector) {
+				continue
+			}
+			args, unpackErr := abiError.Inputs.Unpack(payload)
+			if unpackErr != nil {
+				return revertErr
+			}
+			revertErr.Name = abiError.Name
+			revertErr.Signature = abiError.Sig
+			revertErr.Args = args
+			break
+		}
+	}
+
+	return revertErr
+}
+
+// revertData extracts the revert payload from the error data, which nodes
+// return either as a hex string or nested in an object.
+func revertData(raw json.RawMessage) []byte {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		if index := strings.Index(str, "0x"); index >= 0 {
+			return common.FromHex(strings.TrimSpace(str[index:]))
+		}
+		return nil
+	}
+
+	var object struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil {
+		return revertData(object.Data)
+	}
+
+	return nil
+}
@@ -1,51 +1,94 @@
 package internal
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
+	"sync/atomic"
 
 	"github.com/sunsetlover36/mjolnir/types"
 )
 
 type RpcClient struct {
-	rpcUrl string
-	chain  types.Chain
+	transport types.Transport
+	batcher   *autoBatcher
+	retry     *retryPolicy
+	chain     types.Chain
+	nonces    types.NonceManager
+	fees      types.FeeStrategy
+	nextId    atomic.Int64
 }
 
 func NewRpcClient(params types.NewRpcClientParams) *RpcClient {
-	return &RpcClient{chain: params.Chain, rpcUrl: params.RpcUrl}
-}
-
-func (c *RpcClient) Call(method string, params interface{}) (json.RawMessage, error) {
-	requestBody := types.RpcRequest{
-		Jsonrpc: "2.0",
-		Method:  method,
-		Params:  params,
-		Id:      1,
+	transport := params.Transport
+	if transport == nil {
+		transport = NewHttpTransport(types.NewHttpTransportParams{Url: params.RpcUrl})
 	}
 
-	jsonData, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("error marshalling request: %w", err)
+	client := &RpcClient{
+		chain:     params.Chain,
+		transport: transport,
+		retry:     newRetryPolicy(params.Retry),
+		nonces:    params.NonceManager,
+		fees:      params.FeeStrategy,
+	}
+	if client.fees == nil {
+		client.fees = NewFeeHistoryStrategy(types.EstimateFeesPerGasParams{})
 	}
+	if params.Batch != nil {
+		client.batcher = newAutoBatcher(client, *params.Batch)
+	}
+
+	return client
+}
+
+func (c *RpcClient) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.retry.do(ctx, func() (bool, error) {
+		requestBody := types.RpcRequest{
+			Jsonrpc: "2.0",
+			Method:  method,
+			Params:  params,
+			Id:      int(c.nextId.Add(1)),
+		}
 
-	resp, err := http.Post(c.rpcUrl, "application/json", bytes.NewBuffer(jsonData))
+		response, err := c.request(ctx, requestBody)
+		if err != nil {
+			return isRetryableTransportError(err), err
+		}
+
+		if response.Error != nil {
+			return isRetryableRpcError(response.Error, c.retry.retryableCodes), response.Error
+		}
+
+		result = response.Result
+		return false, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		if isTimeoutError(err) {
+			return nil, &types.TimeoutError{Method: method, Err: err}
+		}
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var response types.RpcResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	return result, nil
+}
+
+func (c *RpcClient) request(ctx context.Context, request types.RpcRequest) (*types.RpcResponse, error) {
+	if collector, ok := ctx.Value(batchCollectorKey{}).(*batchCollector); ok && collector.client == c {
+		call := newPendingCall(request)
+		collector.enqueue(call)
+		return call.wait(ctx)
 	}
 
-	if response.Error != nil {
-		return nil, fmt.Errorf("rpc error: %s", response.Error.Message)
+	if c.batcher != nil {
+		call := newPendingCall(request)
+		c.batcher.enqueue(call)
+		return call.wait(ctx)
 	}
 
-	return response.Result, nil
+	return c.transport.Request(ctx, request)
+}
+
+func (c *RpcClient) Close() error {
+	return c.transport.Close()
 }
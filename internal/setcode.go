@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// setCodeTxType is the EIP-7702 transaction type, which the go-ethereum
+// version we depend on cannot represent, so it is encoded here.
+const setCodeTxType = 0x04
+
+type rlpAuthorization struct {
+	ChainId *big.Int
+	Address common.Address
+	Nonce   uint64
+	YParity uint8
+	R       *big.Int
+	S       *big.Int
+}
+
+// signSetCodeTx turns the fields of an unsigned dynamic fee transaction into
+// a signed set-code transaction and returns its encoding and hash.
+func signSetCodeTx(ctx context.Context, tx *ethTypes.Transaction, chainId *big.Int, authorizationList []types.SignedAuthorization, signer types.Signer) ([]byte, common.Hash, error) {
+	if len(authorizationList) == 0 {
+		return nil, common.Hash{}, fmt.Errorf("set-code transactions require a non-empty authorization list")
+	}
+	if tx.To() == nil {
+		return nil, common.Hash{}, fmt.Errorf("set-code transactions require a recipient")
+	}
+
+	authorizations := make([]rlpAuthorization, 0, len(authorizationList))
+	for _, authorization := range authorizationList {
+		authChainId := authorization.ChainId
+		if authChainId == nil {
+			authChainId = new(big.Int)
+		}
+		authorizations = append(authorizations, rlpAuthorization{
+			ChainId: authChainId,
+			Address: common.HexToAddress(authorization.Address),
+			Nonce:   authorization.Nonce,
+			YParity: authorization.YParity,
+			R:       authorization.R,
+			S:       authorization.S,
+		})
+	}
+
+	accessList := tx.AccessList()
+	if accessList == nil {
+		accessList = ethTypes.AccessList{}
+	}
+	fields := []interface{}{
+		chainId,
+		tx.Nonce(),
+		tx.GasTipCap(),
+		tx.GasFeeCap(),
+		tx.Gas(),
+		*tx.To(),
+		tx.Value(),
+		tx.Data(),
+		accessList,
+		authorizations,
+	}
+
+	payload, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to encode transaction: %v", err)
+	}
+	signature, err := signDigest(ctx, signer, crypto.Keccak256([]byte{setCodeTxType}, payload))
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	fields = append(fields,
+		signature[64],
+		new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:64]),
+	)
+	payload, err = rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to encode transaction: %v", err)
+	}
+
+	rawTx := append([]byte{setCodeTxType}, payload...)
+	return rawTx, crypto.Keccak256Hash(rawTx), nil
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// HashMessage returns the EIP-191 personal_sign digest of message.
+func HashMessage(message []byte) string {
+	return hexutil.Encode(accounts.TextHash(message))
+}
+
+// recoverAddress returns the signer of hash. v may be 0/1 or 27/28.
+func recoverAddress(hash []byte, signatureHex string) (string, error) {
+	signature, err := hexutil.Decode(signatureHex)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode signature: %v", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return "", fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+
+	signature = append([]byte{}, signature...)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	if signature[crypto.RecoveryIDOffset] > 1 {
+		return "", fmt.Errorf("invalid signature recovery id: %d", signature[crypto.RecoveryIDOffset])
+	}
+
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover signer: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey).Hex(), nil
+}
+
+func RecoverMessageAddress(message []byte, signature string) (string, error) {
+	return recoverAddress(accounts.TextHash(message), signature)
+}
+
+func VerifyMessage(address string, message []byte, signature string) (bool, error) {
+	signer, err := RecoverMessageAddress(message, signature)
+	if err != nil {
+		return false, err
+	}
+	return common.HexToAddress(signer) == common.HexToAddress(address), nil
+}
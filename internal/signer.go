@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type localSigner struct {
+	account *types.Account
+}
+
+// NewLocalSigner returns a Signer backed by the private key of account.
+func NewLocalSigner(account *types.Account) types.Signer {
+	return &localSigner{account: account}
+}
+
+func (s *localSigner) Address() string {
+	return s.account.Address
+}
+
+func (s *localSigner) SignTransaction(ctx context.Context, tx *ethTypes.Transaction, chainId *big.Int) (*ethTypes.Transaction, error) {
+	if s.account.PrivateKey == nil {
+		return nil, fmt.Errorf("account %s has no private key", s.account.Address)
+	}
+	return ethTypes.SignTx(tx, ethTypes.LatestSignerForChainID(chainId), s.account.PrivateKey)
+}
+
+func (s *localSigner) SignHash(ctx context.Context, hash []byte) (string, error) {
+	return s.account.SignHash(hash)
+}
+
+func (s *localSigner) SignMessage(ctx context.Context, message []byte) (string, error) {
+	return s.account.SignMessage(message)
+}
+
+func (s *localSigner) SignTypedData(ctx context.Context, typedData types.TypedData) (string, error) {
+	return SignTypedData(s.account, typedData)
+}
+
+// resolveSigner prefers an explicit signer and falls back to a local signer
+// for account.
+func resolveSigner(signer types.Signer, account *types.Account) types.Signer {
+	if signer == nil && account != nil {
+		return NewLocalSigner(account)
+	}
+	return signer
+}
+
+// signDigest signs hash with signer and returns the signature with v set to
+// 0 or 1, as crypto.Sign does.
+func signDigest(ctx context.Context, signer types.Signer, hash []byte) ([]byte, error) {
+	signatureHex, err := signer.SignHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hexutil.Decode(signatureHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	if signature[64] > 1 {
+		return nil, fmt.Errorf("invalid signature recovery id %d", signature[64])
+	}
+	return signature, nil
+}
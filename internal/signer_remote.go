@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// remoteSigner delegates signing to an external service speaking the
+// eth_signTransaction, eth_sign and eth_signTypedData_v4 methods, such as
+// Clef or Web3Signer, so keys never enter the process.
+type remoteSigner struct {
+	client  *RpcClient
+	address string
+}
+
+// NewRemoteSigner connects to the signing service and, unless params.Address
+// is set, signs for its first account.
+func NewRemoteSigner(ctx context.Context, params types.NewRemoteSignerParams) (types.Signer, error) {
+	signer := &remoteSigner{
+		client: NewRpcClient(types.NewRpcClientParams{
+			RpcUrl:    params.RpcUrl,
+			Transport: params.Transport,
+			Retry:     params.Retry,
+		}),
+		address: params.Address,
+	}
+	if signer.address != "" {
+		if !common.IsHexAddress(signer.address) {
+			return nil, fmt.Errorf("invalid signer address: %s", signer.address)
+		}
+		signer.address = common.HexToAddress(signer.address).Hex()
+		return signer, nil
+	}
+
+	result, err := signer.client.Call(ctx, "eth_accounts", []interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signer accounts: %w", err)
+	}
+	var accounts []common.Address
+	if err := json.Unmarshal(result, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signer accounts: %v", err)
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("remote signer has no accounts")
+	}
+	signer.address = accounts[0].Hex()
+
+	return signer, nil
+}
+
+func (s *remoteSigner) Address() string {
+	return s.address
+}
+
+func (s *remoteSigner) SignTransaction(ctx context.Context, tx *ethTypes.Transaction, chainId *big.Int) (*ethTypes.Transaction, error) {
+	args := map[string]interface{}{
+		"from":    s.address,
+		"nonce":   hexutil.Uint64(tx.Nonce()),
+		"gas":     hexutil.Uint64(tx.Gas()),
+		"value":   (*hexutil.Big)(tx.Value()),
+		"data":    hexutil.Bytes(tx.Data()),
+		"chainId": (*hexutil.Big)(chainId),
+	}
+	if tx.To() != nil {
+		args["to"] = tx.To().Hex()
+	}
+	// The service picks the transaction type from the fee fields and the
+	// presence of an access list.
+	accessList := tx.AccessList()
+	if accessList == nil {
+		accessList = ethTypes.AccessList{}
+	}
+	switch tx.Type() {
+	case ethTypes.LegacyTxType:
+		args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+	case ethTypes.AccessListTxType:
+		args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+		args["accessList"] = accessList
+	case ethTypes.DynamicFeeTxType:
+		args["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+		args["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+		args["accessList"] = accessList
+	default:
+		return nil, fmt.Errorf("remote signers cannot sign type %d transactions", tx.Type())
+	}
+
+	result, err := s.client.Call(ctx, "eth_signTransaction", []interface{}{args})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign transaction: %w", err)
+	}
+
+	// Web3Signer returns the raw transaction, Clef wraps it with the decoded
+	// transaction.
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var signed struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(result, &signed); err != nil || len(signed.Raw) == 0 {
+			return nil, fmt.Errorf("unexpected eth_signTransaction result: %s", result)
+		}
+		raw = signed.Raw
+	}
+	signedTx := new(ethTypes.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+	}
+
+	txSigner := ethTypes.LatestSignerForChainID(chainId)
+	if txSigner.Hash(signedTx) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction")
+	}
+	sender, err := ethTypes.Sender(txSigner, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction signer: %v", err)
+	}
+	if sender != common.HexToAddress(s.address) {
+		return nil, fmt.Errorf("transaction was signed by %s, not by %s", sender.Hex(), s.address)
+	}
+
+	return signedTx, nil
+}
+
+// SignHash is not supported: eth_sign always prefixes the message, so the
+// service cannot sign a raw digest.
+func (s *remoteSigner) SignHash(ctx context.Context, hash []byte) (string, error) {
+	return "", fmt.Errorf("remote signers cannot sign raw hashes")
+}
+
+func (s *remoteSigner) SignMessage(ctx context.Context, message []byte) (string, error) {
+	result, err := s.client.Call(ctx, "eth_sign", []interface{}{s.address, hexutil.Bytes(message)})
+	if err != nil {
+		return "", fmt.Errorf("remote signer failed to sign message: %w", err)
+	}
+	return remoteSignature(result)
+}
+
+func (s *remoteSigner) SignTypedData(ctx context.Context, typedData types.TypedData) (string, error) {
+	payload, err := typedDataJson(typedData)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.client.Call(ctx, "eth_signTypedData_v4", []interface{}{s.address, payload})
+	if err != nil {
+		return "", fmt.Errorf("remote signer failed to sign typed data: %w", err)
+	}
+	return remoteSignature(result)
+}
+
+// remoteSignature decodes a signature returned by the service and sets v to
+// 27 or 28, as Account.SignHash does.
+func remoteSignature(result json.RawMessage) (string, error) {
+	var signature hexutil.Bytes
+	if err := json.Unmarshal(result, &signature); err != nil {
+		return "", fmt.Errorf("failed to unmarshal signature: %v", err)
+	}
+	if len(signature) != 65 {
+		return "", fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	return hexutil.Encode(signature), nil
+}
@@ -0,0 +1,355 @@
+package internal
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// signTxArgs is the transaction object of eth_signTransaction.
+type signTxArgs struct {
+	From                 common.Address       `json:"from"`
+	To                   *common.Address      `json:"to"`
+	Nonce                hexutil.Uint64       `json:"nonce"`
+	Gas                  hexutil.Uint64       `json:"gas"`
+	Value                *hexutil.Big         `json:"value"`
+	Data                 hexutil.Bytes        `json:"data"`
+	ChainId              *hexutil.Big         `json:"chainId"`
+	GasPrice             *hexutil.Big         `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big         `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big         `json:"maxPriorityFeePerGas"`
+	AccessList           *ethTypes.AccessList `json:"accessList"`
+}
+
+// transaction picks the type from the fee fields and the access list, as
+// Clef does.
+func (a signTxArgs) transaction() *ethTypes.Transaction {
+	switch {
+	case a.MaxFeePerGas != nil:
+		return ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+			ChainID:    a.ChainId.ToInt(),
+			Nonce:      uint64(a.Nonce),
+			GasTipCap:  a.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap:  a.MaxFeePerGas.ToInt(),
+			Gas:        uint64(a.Gas),
+			To:         a.To,
+			Value:      a.Value.ToInt(),
+			Data:       a.Data,
+			AccessList: *a.AccessList,
+		})
+	case a.AccessList != nil:
+		return ethTypes.NewTx(&ethTypes.AccessListTx{
+			ChainID:    a.ChainId.ToInt(),
+			Nonce:      uint64(a.Nonce),
+			GasPrice:   a.GasPrice.ToInt(),
+			Gas:        uint64(a.Gas),
+			To:         a.To,
+			Value:      a.Value.ToInt(),
+			Data:       a.Data,
+			AccessList: *a.AccessList,
+		})
+	}
+	return ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    uint64(a.Nonce),
+		GasPrice: a.GasPrice.ToInt(),
+		Gas:      uint64(a.Gas),
+		To:       a.To,
+		Value:    a.Value.ToInt(),
+		Data:     a.Data,
+	})
+}
+
+// signingStandIn is a stand-in signing service holding key.
+type signingStandIn struct {
+	key *ecdsa.PrivateKey
+	// signTransaction shapes the eth_signTransaction result for the
+	// transaction the service was asked to sign.
+	signTransaction func(t *testing.T, tx *ethTypes.Transaction) interface{}
+	// vOffset is added to v of eth_sign and eth_signTypedData_v4
+	// signatures, 0 or 27 depending on the service.
+	vOffset byte
+}
+
+func (s *signingStandIn) handler(t *testing.T) rpcHandler {
+	address := crypto.PubkeyToAddress(s.key.PublicKey)
+	return func(request types.RpcRequest) types.RpcResponse {
+		params := decodeRpcParams(t, request)
+		switch request.Method {
+		case "eth_accounts":
+			return rpcResult(request, []common.Address{address})
+		case "eth_signTransaction":
+			var args signTxArgs
+			json.Unmarshal(params[0], &args)
+			if args.From != address {
+				return rpcFailure(request, -32000, "unknown account")
+			}
+			return rpcResult(request, s.signTransaction(t, args.transaction()))
+		case "eth_sign":
+			var data hexutil.Bytes
+			json.Unmarshal(params[1], &data)
+			return rpcResult(request, s.sign(t, accounts.TextHash(data)))
+		case "eth_signTypedData_v4":
+			var typedData types.TypedData
+			if err := json.Unmarshal(params[1], &typedData); err != nil {
+				return rpcFailure(request, -32602, err.Error())
+			}
+			hash, err := hashTypedData(typedData)
+			if err != nil {
+				return rpcFailure(request, -32602, err.Error())
+			}
+			return rpcResult(request, s.sign(t, hash))
+		}
+		return rpcFailure(request, -32601, "unexpected call to "+request.Method)
+	}
+}
+
+func (s *signingStandIn) sign(t *testing.T, hash []byte) hexutil.Bytes {
+	signature, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		t.Error(err)
+	}
+	signature[64] += s.vOffset
+	return signature
+}
+
+func decodeRpcParams(t *testing.T, request types.RpcRequest) []json.RawMessage {
+	data, _ := json.Marshal(request.Params)
+	var params []json.RawMessage
+	if err := json.Unmarshal(data, &params); err != nil {
+		t.Errorf("invalid params for %s: %s", request.Method, data)
+	}
+	return params
+}
+
+func signedRaw(t *testing.T, tx *ethTypes.Transaction, chainId *big.Int, key *ecdsa.PrivateKey) hexutil.Bytes {
+	signed, err := ethTypes.SignTx(tx, ethTypes.LatestSignerForChainID(chainId), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func newTestRemoteSigner(t *testing.T, standIn *signingStandIn) types.Signer {
+	t.Helper()
+	server := newHttpStandIn(t, standIn.handler(t))
+	signer, err := NewRemoteSigner(context.Background(), types.NewRemoteSignerParams{RpcUrl: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestNewRemoteSignerAccount(t *testing.T) {
+	account := testAccount(t)
+	other := testAccount(t)
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		return rpcResult(request, []string{strings.ToLower(account.Address), other.Address})
+	})
+	signer, err := NewRemoteSigner(context.Background(), types.NewRemoteSignerParams{RpcUrl: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address() != account.Address {
+		t.Errorf("got address %s, want the first account %s", signer.Address(), account.Address)
+	}
+
+	empty := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		return rpcResult(request, []string{})
+	})
+	if _, err := NewRemoteSigner(context.Background(), types.NewRemoteSignerParams{RpcUrl: empty.URL}); err == nil {
+		t.Error("expected an error for a service without accounts")
+	}
+}
+
+func TestNewRemoteSignerExplicitAddress(t *testing.T) {
+	account := testAccount(t)
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		t.Errorf("unexpected call to %s", request.Method)
+		return rpcFailure(request, -32601, "unexpected call")
+	})
+
+	signer, err := NewRemoteSigner(context.Background(), types.NewRemoteSignerParams{
+		RpcUrl:  server.URL,
+		Address: strings.ToLower(account.Address),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address() != account.Address {
+		t.Errorf("got address %s, want %s", signer.Address(), account.Address)
+	}
+
+	if _, err := NewRemoteSigner(context.Background(), types.NewRemoteSignerParams{RpcUrl: server.URL, Address: "0x1234"}); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestRemoteSignerSignTransaction(t *testing.T) {
+	chainId := big.NewInt(1)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	transactions := map[string]*ethTypes.Transaction{
+		"legacy": ethTypes.NewTx(&ethTypes.LegacyTx{
+			Nonce: 1, GasPrice: big.NewInt(2e9), Gas: 21000, To: &to, Value: big.NewInt(1),
+		}),
+		"access list": ethTypes.NewTx(&ethTypes.AccessListTx{
+			ChainID: chainId, Nonce: 2, GasPrice: big.NewInt(2e9), Gas: 30000, To: &to, Value: big.NewInt(1),
+			AccessList: ethTypes.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}},
+		}),
+		"dynamic fee": ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+			ChainID: chainId, Nonce: 3, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(3e9), Gas: 50000,
+			Value: new(big.Int), Data: []byte{0x60, 0x80},
+		}),
+	}
+	results := map[string]func(t *testing.T, tx *ethTypes.Transaction, key *ecdsa.PrivateKey) interface{}{
+		"raw": func(t *testing.T, tx *ethTypes.Transaction, key *ecdsa.PrivateKey) interface{} {
+			return signedRaw(t, tx, chainId, key)
+		},
+		"clef": func(t *testing.T, tx *ethTypes.Transaction, key *ecdsa.PrivateKey) interface{} {
+			raw := signedRaw(t, tx, chainId, key)
+			signed := new(ethTypes.Transaction)
+			signed.UnmarshalBinary(raw)
+			return map[string]interface{}{"raw": raw, "tx": signed}
+		},
+	}
+
+	for txName, tx := range transactions {
+		for resultName, result := range results {
+			t.Run(txName+"/"+resultName, func(t *testing.T) {
+				account := testAccount(t)
+				signer := newTestRemoteSigner(t, &signingStandIn{
+					key: account.PrivateKey,
+					signTransaction: func(t *testing.T, requested *ethTypes.Transaction) interface{} {
+						return result(t, requested, account.PrivateKey)
+					},
+				})
+
+				signed, err := signer.SignTransaction(context.Background(), tx, chainId)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want, _ := NewLocalSigner(account).SignTransaction(context.Background(), tx, chainId)
+				if signed.Hash() != want.Hash() {
+					t.Errorf("got transaction %s, want %s", signed.Hash(), want.Hash())
+				}
+			})
+		}
+	}
+}
+
+func TestRemoteSignerRejectsTransaction(t *testing.T) {
+	chainId := big.NewInt(1)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx := ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+		ChainID: chainId, Nonce: 3, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(3e9), Gas: 21000,
+		To: &to, Value: big.NewInt(1),
+	})
+	account := testAccount(t)
+	other := testAccount(t)
+
+	tests := map[string]struct {
+		signTransaction func(t *testing.T, tx *ethTypes.Transaction) interface{}
+		want            string
+	}{
+		"different transaction": {
+			signTransaction: func(t *testing.T, tx *ethTypes.Transaction) interface{} {
+				changed := ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+					ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasTipCap: tx.GasTipCap(), GasFeeCap: tx.GasFeeCap(),
+					Gas: tx.Gas(), To: &common.Address{1}, Value: tx.Value(),
+				})
+				return signedRaw(t, changed, chainId, account.PrivateKey)
+			},
+			want: "different transaction",
+		},
+		"wrong sender": {
+			signTransaction: func(t *testing.T, tx *ethTypes.Transaction) interface{} {
+				return signedRaw(t, tx, chainId, other.PrivateKey)
+			},
+			want: "was signed by " + other.Address,
+		},
+		"unexpected result": {
+			signTransaction: func(t *testing.T, tx *ethTypes.Transaction) interface{} {
+				return map[string]interface{}{"tx": tx}
+			},
+			want: "unexpected eth_signTransaction result",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signer := newTestRemoteSigner(t, &signingStandIn{key: account.PrivateKey, signTransaction: test.signTransaction})
+			_, err := signer.SignTransaction(context.Background(), tx, chainId)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestRemoteSignerSignatures(t *testing.T) {
+	message := []byte("hello mjolnir")
+	typedData := types.TypedData{
+		Types: map[string][]types.TypedDataField{
+			"Mail": {
+				{Name: "from", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+				{Name: "memo", Type: "bytes"},
+				{Name: "note", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: types.TypedDataDomain{
+			Name:              "Mjolnir",
+			Version:           "1",
+			ChainId:           big.NewInt(1),
+			VerifyingContract: "0x000000000000000000000000000000000000dEaD",
+		},
+		Message: map[string]interface{}{
+			"from":   common.HexToAddress("0x000000000000000000000000000000000000bEEF"),
+			"amount": new(big.Int).Lsh(big.NewInt(1), 200),
+			"memo":   []byte{1, 2, 3},
+			"note":   "hi",
+		},
+	}
+
+	for _, vOffset := range []byte{0, 27} {
+		account := testAccount(t)
+		signer := newTestRemoteSigner(t, &signingStandIn{key: account.PrivateKey, vOffset: vOffset})
+
+		got, err := signer.SignMessage(context.Background(), message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := account.SignMessage(message)
+		if got != want {
+			t.Errorf("v offset %d: got message signature %s, want %s", vOffset, got, want)
+		}
+
+		got, err = signer.SignTypedData(context.Background(), typedData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ = SignTypedData(account, typedData)
+		if got != want {
+			t.Errorf("v offset %d: got typed data signature %s, want %s", vOffset, got, want)
+		}
+	}
+
+	account := testAccount(t)
+	signer := newTestRemoteSigner(t, &signingStandIn{key: account.PrivateKey})
+	if _, err := signer.SignHash(context.Background(), make([]byte, 32)); err == nil {
+		t.Error("expected remote signers to refuse raw hashes")
+	}
+}
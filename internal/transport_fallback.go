@@ -0,0 +1,276 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type fallbackSample struct {
+	latency time.Duration
+	success bool
+}
+
+type FallbackTransport struct {
+	transports []types.Transport
+	rank       *types.FallbackRankOptions
+	onResponse func(response types.FallbackResponse)
+
+	mu      sync.Mutex
+	order   []int
+	samples [][]fallbackSample
+	stop    chan struct{}
+	once    sync.Once
+}
+
+func NewFallbackTransport(params types.NewFallbackTransportParams) *FallbackTransport {
+	t := &FallbackTransport{
+		transports: params.Transports,
+		onResponse: params.OnResponse,
+		order:      make([]int, len(params.Transports)),
+		samples:    make([][]fallbackSample, len(params.Transports)),
+		stop:       make(chan struct{}),
+	}
+	for i := range t.order {
+		t.order[i] = i
+	}
+
+	if params.Rank != nil {
+		rank := *params.Rank
+		if rank.Interval <= 0 {
+			rank.Interval = 10 * time.Second
+		}
+		if rank.Timeout <= 0 {
+			rank.Timeout = rank.Interval
+		}
+		if rank.SampleCount <= 0 {
+			rank.SampleCount = 10
+		}
+		if rank.LatencyWeight == 0 && rank.StabilityWeight == 0 {
+			rank.LatencyWeight = 0.3
+			rank.StabilityWeight = 0.7
+		}
+		t.rank = &rank
+		go t.rankLoop()
+	}
+
+	return t
+}
+
+func (t *FallbackTransport) Request(ctx context.Context, request types.RpcRequest) (*types.RpcResponse, error) {
+	var response, lastRpcErrorResponse *types.RpcResponse
+	err := t.try(ctx, []string{request.Method}, func(transport types.Transport) error {
+		var err error
+		response, err = transport.Request(ctx, request)
+		if err != nil {
+			return err
+		}
+		if response.Error != nil && shouldFallBack(response.Error) {
+			lastRpcErrorResponse = response
+			return response.Error
+		}
+		return nil
+	})
+	if err != nil {
+		// Prefer the node's own error over a transport failure so the caller
+		// can still inspect it.
+		if lastRpcErrorResponse != nil && ctx.Err() == nil {
+			return lastRpcErrorResponse, nil
+		}
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (t *FallbackTransport) BatchRequest(ctx context.Context, requests []types.RpcRequest) ([]types.RpcResponse, error) {
+	methods := make([]string, len(requests))
+	for i, request := range requests {
+		methods[i] = request.Method
+	}
+
+	var responses []types.RpcResponse
+	err := t.try(ctx, methods, func(transport types.Transport) error {
+		var err error
+		responses, err = transport.BatchRequest(ctx, requests)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// Subscribe subscribes through the first transport, in the current order,
+// that supports subscriptions and accepts the request.
+func (t *FallbackTransport) Subscribe(ctx context.Context, params []interface{}) (types.Subscription, error) {
+	var errs []error
+	for _, index := range t.currentOrder() {
+		transport, ok := t.transports[index].(types.SubscriptionTransport)
+		if !ok {
+			continue
+		}
+		subscription, err := transport.Subscribe(ctx, params)
+		if err == nil {
+			return subscription, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		errs = append(errs, fmt.Errorf("transport %d: %w", index, err))
+	}
+	if len(errs) == 0 {
+		return nil, errSubscriptionsNotSupported
+	}
+
+	return nil, fmt.Errorf("all transports failed: %w", errors.Join(errs...))
+}
+
+// try runs attempt against each transport in the current order until one
+// succeeds, reporting every attempt through onResponse.
+func (t *FallbackTransport) try(ctx context.Context, methods []string, attempt func(transport types.Transport) error) error {
+	if len(t.transports) == 0 {
+		return errors.New("fallback transport has no transports")
+	}
+
+	var errs []error
+	for _, index := range t.currentOrder() {
+		transport := t.transports[index]
+
+		start := time.Now()
+		err := attempt(transport)
+		if t.onResponse != nil {
+			t.onResponse(types.FallbackResponse{
+				Index:     index,
+				Transport: transport,
+				Methods:   methods,
+				Duration:  time.Since(start),
+				Err:       err,
+			})
+		}
+		if err == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		errs = append(errs, fmt.Errorf("transport %d: %w", index, err))
+	}
+
+	return fmt.Errorf("all transports failed: %w", errors.Join(errs...))
+}
+
+func (t *FallbackTransport) currentOrder() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]int(nil), t.order...)
+}
+
+func (t *FallbackTransport) rankLoop() {
+	ticker := time.NewTicker(t.rank.Interval)
+	defer ticker.Stop()
+
+	for {
+		t.probe()
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe samples every transport with eth_blockNumber and reorders them by
+// score = stabilityWeight*successRate + latencyWeight*(1 - latency/maxLatency).
+func (t *FallbackTransport) probe() {
+	var wg sync.WaitGroup
+	results := make([]fallbackSample, len(t.transports))
+	for i, transport := range t.transports {
+		wg.Add(1)
+		go func(i int, transport types.Transport) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), t.rank.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			response, err := transport.Request(ctx, types.RpcRequest{
+				Jsonrpc: "2.0",
+				Method:  "eth_blockNumber",
+				Params:  []interface{}{},
+				Id:      1,
+			})
+			results[i] = fallbackSample{
+				latency: time.Since(start),
+				success: err == nil && response.Error == nil,
+			}
+		}(i, transport)
+	}
+	wg.Wait()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var maxLatency time.Duration
+	for i, sample := range results {
+		t.samples[i] = append(t.samples[i], sample)
+		if len(t.samples[i]) > t.rank.SampleCount {
+			t.samples[i] = t.samples[i][1:]
+		}
+		if sample.latency > maxLatency {
+			maxLatency = sample.latency
+		}
+	}
+
+	scores := make([]float64, len(t.transports))
+	for i, samples := range t.samples {
+		var successes int
+		var latency time.Duration
+		for _, sample := range samples {
+			if sample.success {
+				successes++
+				latency += sample.latency
+			}
+		}
+		stability := float64(successes) / float64(len(samples))
+		latencyScore := 0.0
+		if successes > 0 && maxLatency > 0 {
+			averageLatency := latency / time.Duration(successes)
+			latencyScore = 1 - float64(averageLatency)/float64(maxLatency)
+			if latencyScore < 0 {
+				latencyScore = 0
+			}
+		}
+		scores[i] = t.rank.StabilityWeight*stability + t.rank.LatencyWeight*latencyScore
+	}
+
+	sort.SliceStable(t.order, func(a, b int) bool {
+		return scores[t.order[a]] > scores[t.order[b]]
+	})
+}
+
+func (t *FallbackTransport) Close() error {
+	t.once.Do(func() { close(t.stop) })
+
+	var errs []error
+	for _, transport := range t.transports {
+		if err := transport.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// shouldFallBack reports whether another node may well answer the same
+// request successfully.
+func shouldFallBack(rpcError *types.RpcError) bool {
+	return rpcError.Code == -32601 || isRetryableRpcError(rpcError, defaultRetryableRpcCodes)
+}
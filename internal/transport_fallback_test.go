@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// fakeTransport answers requests with respond and counts its calls.
+type fakeTransport struct {
+	respond func(request types.RpcRequest) (*types.RpcResponse, error)
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeTransport) Request(ctx context.Context, request types.RpcRequest) (*types.RpcResponse, error) {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+	return f.respond(request)
+}
+
+func (f *fakeTransport) BatchRequest(ctx context.Context, requests []types.RpcRequest) ([]types.RpcResponse, error) {
+	responses := make([]types.RpcResponse, len(requests))
+	for i, request := range requests {
+		response, err := f.Request(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = *response
+	}
+	return responses, nil
+}
+
+func (f *fakeTransport) Close() error {
+	return nil
+}
+
+func (f *fakeTransport) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func failingTransport(err error) *fakeTransport {
+	return &fakeTransport{respond: func(request types.RpcRequest) (*types.RpcResponse, error) {
+		return nil, err
+	}}
+}
+
+func answeringTransport(code int, message string) *fakeTransport {
+	return &fakeTransport{respond: func(request types.RpcRequest) (*types.RpcResponse, error) {
+		if message != "" {
+			response := rpcFailure(request, code, message)
+			return &response, nil
+		}
+		response := echoHandler(request)
+		return &response, nil
+	}}
+}
+
+func TestFallbackTransportTriesInOrder(t *testing.T) {
+	first := failingTransport(errors.New("connection refused"))
+	second := answeringTransport(0, "")
+	third := answeringTransport(0, "")
+	var attempts []string
+	transport := NewFallbackTransport(types.NewFallbackTransportParams{
+		Transports: []types.Transport{first, second, third},
+		OnResponse: func(response types.FallbackResponse) {
+			attempts = append(attempts, fmt.Sprintf("%d:%v", response.Index, response.Err != nil))
+		},
+	})
+	defer transport.Close()
+
+	response, err := transport.Request(context.Background(), testRequest(1, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, response, 1, "a")
+	if fmt.Sprint(attempts) != "[0:true 1:false]" {
+		t.Errorf("got attempts %v, want [0:true 1:false]", attempts)
+	}
+	if third.callCount() != 0 {
+		t.Errorf("third transport was called %d times", third.callCount())
+	}
+}
+
+func TestFallbackTransportFallsBackOnNodeErrors(t *testing.T) {
+	first := answeringTransport(-32601, "the method eth_foo does not exist")
+	second := answeringTransport(0, "")
+	transport := NewFallbackTransport(types.NewFallbackTransportParams{Transports: []types.Transport{first, second}})
+
+	response, err := transport.Request(context.Background(), testRequest(1, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, response, 1, "a")
+}
+
+func TestFallbackTransportKeepsExecutionErrors(t *testing.T) {
+	first := answeringTransport(3, "execution reverted")
+	second := answeringTransport(0, "")
+	transport := NewFallbackTransport(types.NewFallbackTransportParams{Transports: []types.Transport{first, second}})
+
+	response, err := transport.Request(context.Background(), testRequest(1, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Error == nil || response.Error.Message != "execution reverted" {
+		t.Errorf("got response error %v, want the first node's revert", response.Error)
+	}
+	if second.callCount() != 0 {
+		t.Errorf("second transport was called %d times", second.callCount())
+	}
+}
+
+func TestFallbackTransportReturnsLastNodeError(t *testing.T) {
+	first := failingTransport(errors.New("connection refused"))
+	second := answeringTransport(-32005, "rate limit exceeded")
+	transport := NewFallbackTransport(types.NewFallbackTransportParams{Transports: []types.Transport{first, second}})
+
+	response, err := transport.Request(context.Background(), testRequest(1, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Error == nil || response.Error.Code != -32005 {
+		t.Errorf("got response error %v, want the node's rate limit error", response.Error)
+	}
+}
+
+func TestFallbackTransportAllFail(t *testing.T) {
+	transport := NewFallbackTransport(types.NewFallbackTransportParams{Transports: []types.Transport{
+		failingTransport(errors.New("first down")),
+		failingTransport(errors.New("second down")),
+	}})
+
+	_, err := transport.Request(context.Background(), testRequest(1, "a"))
+	if err == nil || !strings.Contains(err.Error(), "first down") || !strings.Contains(err.Error(), "second down") {
+		t.Fatalf("got error %v, want both failures", err)
+	}
+
+	_, err = transport.BatchRequest(context.Background(), []types.RpcRequest{testRequest(1, "a")})
+	if err == nil || !strings.Contains(err.Error(), "all transports failed") {
+		t.Fatalf("got batch error %v", err)
+	}
+}
+
+func TestFallbackTransportRanksByStability(t *testing.T) {
+	unstable := failingTransport(errors.New("connection refused"))
+	stable := answeringTransport(0, "")
+	transport := NewFallbackTransport(types.NewFallbackTransportParams{
+		Transports: []types.Transport{unstable, stable},
+		Rank:       &types.FallbackRankOptions{Interval: time.Hour},
+	})
+	defer transport.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for fmt.Sprint(transport.currentOrder()) != "[1 0]" {
+		if time.Now().After(deadline) {
+			t.Fatalf("got order %v, want [1 0]", transport.currentOrder())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, err := transport.Request(context.Background(), testRequest(1, "a")); err != nil {
+		t.Fatal(err)
+	}
+	// One probe plus the request went to the stable transport, only the
+	// probe to the unstable one.
+	if unstable.callCount() != 1 || stable.callCount() != 2 {
+		t.Errorf("got %d unstable and %d stable calls, want 1 and 2", unstable.callCount(), stable.callCount())
+	}
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type HttpTransport struct {
+	url         string
+	httpClient  *http.Client
+	headers     http.Header
+	basicAuth   *types.BasicAuth
+	bearerToken string
+}
+
+func NewHttpTransport(params types.NewHttpTransportParams) *HttpTransport {
+	httpClient := params.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &HttpTransport{
+		url:         params.Url,
+		httpClient:  httpClient,
+		headers:     params.Headers.Clone(),
+		basicAuth:   params.BasicAuth,
+		bearerToken: params.BearerToken,
+	}
+}
+
+func (t *HttpTransport) Request(ctx context.Context, request types.RpcRequest) (*types.RpcResponse, error) {
+	var response types.RpcResponse
+	if err := t.post(ctx, request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (t *HttpTransport) BatchRequest(ctx context.Context, requests []types.RpcRequest) ([]types.RpcResponse, error) {
+	var raw json.RawMessage
+	if err := t.post(ctx, requests, &raw); err != nil {
+		return nil, err
+	}
+
+	// Some nodes reject a whole batch with a single error object.
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '{' {
+		var response types.RpcResponse
+		if err := json.Unmarshal(raw, &response); err != nil {
+			return nil, fmt.Errorf("error decoding response: %w", err)
+		}
+		if response.Error != nil {
+			return nil, response.Error
+		}
+		return nil, fmt.Errorf("unexpected batch response: %s", raw)
+	}
+
+	var responses []types.RpcResponse
+	if err := json.Unmarshal(raw, &responses); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return responses, nil
+}
+
+func (t *HttpTransport) post(ctx context.Context, body interface{}, out interface{}) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	for key, values := range t.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if t.basicAuth != nil {
+		req.SetBasicAuth(t.basicAuth.Username, t.basicAuth.Password)
+	}
+	if t.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+t.bearerToken)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &types.HttpStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			Body:       body,
+		}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+func (t *HttpTransport) Close() error {
+	t.httpClient.CloseIdleConnections()
+	return nil
+}
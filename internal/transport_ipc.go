@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type ipcConn struct {
+	conn    net.Conn
+	decoder *json.Decoder
+}
+
+func (c *ipcConn) WriteMessage(data []byte) error {
+	_, err := c.conn.Write(data)
+	return err
+}
+
+func (c *ipcConn) ReadMessage() (json.RawMessage, error) {
+	var message json.RawMessage
+	err := c.decoder.Decode(&message)
+	return message, err
+}
+
+func (c *ipcConn) Close() error {
+	return c.conn.Close()
+}
+
+type IpcTransport struct {
+	*streamTransport
+}
+
+func NewIpcTransport(params types.NewIpcTransportParams) *IpcTransport {
+	dial := func(ctx context.Context) (streamConn, error) {
+		if params.DialTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, params.DialTimeout)
+			defer cancel()
+		}
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "unix", params.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ipcConn{conn: conn, decoder: json.NewDecoder(conn)}, nil
+	}
+
+	return &IpcTransport{streamTransport: newStreamTransport(dial)}
+}
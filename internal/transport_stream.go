@@ -0,0 +1,390 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+var errTransportClosed = errors.New("transport closed")
+
+type streamConn interface {
+	WriteMessage(data []byte) error
+	ReadMessage() (json.RawMessage, error)
+	Close() error
+}
+
+type streamResult struct {
+	response *types.RpcResponse
+	err      error
+}
+
+type streamPending struct {
+	ch chan streamResult
+	// subscription is registered as soon as the eth_subscribe response is
+	// read, so no notification that follows it can be missed.
+	subscription *streamSubscription
+}
+
+// streamTransport multiplexes concurrent requests over a single persistent
+// connection, matching responses by id. The connection is dialed lazily and
+// re-dialed on the next request after it breaks.
+type streamTransport struct {
+	dial func(ctx context.Context) (streamConn, error)
+
+	mu            sync.Mutex
+	writeMu       sync.Mutex
+	conn          streamConn
+	pending       map[int]*streamPending
+	subscriptions map[string]*streamSubscription
+	nextId        int
+	closed        bool
+}
+
+func newStreamTransport(dial func(ctx context.Context) (streamConn, error)) *streamTransport {
+	return &streamTransport{
+		dial:          dial,
+		pending:       make(map[int]*streamPending),
+		subscriptions: make(map[string]*streamSubscription),
+		nextId:        1,
+	}
+}
+
+func (t *streamTransport) connect(ctx context.Context) (streamConn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, errTransportClosed
+	}
+	if t.conn != nil {
+		return t.conn, nil
+	}
+
+	conn, err := t.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing transport: %w", err)
+	}
+	t.conn = conn
+	go t.readLoop(conn)
+
+	return conn, nil
+}
+
+func (t *streamTransport) Request(ctx context.Context, request types.RpcRequest) (*types.RpcResponse, error) {
+	responses, err := t.roundTrip(ctx, []types.RpcRequest{request}, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+func (t *streamTransport) BatchRequest(ctx context.Context, requests []types.RpcRequest) ([]types.RpcResponse, error) {
+	return t.roundTrip(ctx, requests, true, nil)
+}
+
+func (t *streamTransport) Subscribe(ctx context.Context, params []interface{}) (types.Subscription, error) {
+	subscription := newStreamSubscription(t)
+	responses, err := t.roundTrip(ctx, []types.RpcRequest{{
+		Jsonrpc: "2.0",
+		Method:  "eth_subscribe",
+		Params:  params,
+	}}, false, subscription)
+	if err != nil {
+		return nil, err
+	}
+	if responses[0].Error != nil {
+		return nil, responses[0].Error
+	}
+	if subscription.id == "" {
+		return nil, fmt.Errorf("invalid subscription id: %s", responses[0].Result)
+	}
+
+	return subscription, nil
+}
+
+// roundTrip writes the requests under connection-unique ids and waits for
+// every response, restoring the caller's ids before returning them.
+func (t *streamTransport) roundTrip(ctx context.Context, requests []types.RpcRequest, batch bool, subscription *streamSubscription) ([]types.RpcResponse, error) {
+	conn, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests = append([]types.RpcRequest(nil), requests...)
+	originalIds := make([]int, len(requests))
+	channels := make([]chan streamResult, len(requests))
+
+	t.mu.Lock()
+	for i := range requests {
+		originalIds[i] = requests[i].Id
+		requests[i].Id = t.nextId
+		t.nextId++
+		channels[i] = make(chan streamResult, 1)
+		t.pending[requests[i].Id] = &streamPending{ch: channels[i], subscription: subscription}
+	}
+	t.mu.Unlock()
+	defer func() {
+		for _, request := range requests {
+			t.forget(request.Id)
+		}
+	}()
+
+	var jsonData []byte
+	if batch {
+		jsonData, err = json.Marshal(requests)
+	} else {
+		jsonData, err = json.Marshal(requests[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling request: %w", err)
+	}
+
+	t.writeMu.Lock()
+	err = conn.WriteMessage(jsonData)
+	t.writeMu.Unlock()
+	if err != nil {
+		t.drop(conn, err)
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+
+	responses := make([]types.RpcResponse, len(requests))
+	for i, ch := range channels {
+		select {
+		case <-ctx.Done():
+			if subscription != nil {
+				subscription.Unsubscribe()
+			}
+			return nil, ctx.Err()
+		case result := <-ch:
+			if result.err != nil {
+				return nil, result.err
+			}
+			responses[i] = *result.response
+			responses[i].Id = originalIds[i]
+		}
+	}
+
+	return responses, nil
+}
+
+func (t *streamTransport) forget(id int) {
+	t.mu.Lock()
+	delete(t.pending, id)
+	t.mu.Unlock()
+}
+
+func (t *streamTransport) readLoop(conn streamConn) {
+	for {
+		message, err := conn.ReadMessage()
+		if err != nil {
+			t.drop(conn, fmt.Errorf("error reading response: %w", err))
+			return
+		}
+		t.dispatch(conn, message)
+	}
+}
+
+type streamNotification struct {
+	Method string `json:"method"`
+	Params struct {
+		Subscription string          `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+func (t *streamTransport) dispatch(conn streamConn, message json.RawMessage) {
+	message = bytes.TrimSpace(message)
+	if len(message) > 0 && message[0] == '[' {
+		var messages []json.RawMessage
+		if err := json.Unmarshal(message, &messages); err != nil {
+			return
+		}
+		for _, m := range messages {
+			t.dispatch(conn, m)
+		}
+		return
+	}
+
+	var notification streamNotification
+	if err := json.Unmarshal(message, &notification); err == nil && notification.Method == "eth_subscription" {
+		t.mu.Lock()
+		subscription, ok := t.subscriptions[notification.Params.Subscription]
+		t.mu.Unlock()
+		if ok {
+			subscription.deliver(notification.Params.Result)
+		}
+		return
+	}
+
+	var response types.RpcResponse
+	if err := json.Unmarshal(message, &response); err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	pending, ok := t.pending[response.Id]
+	delete(t.pending, response.Id)
+	if ok && pending.subscription != nil && response.Error == nil {
+		var id string
+		if err := json.Unmarshal(response.Result, &id); err == nil && id != "" {
+			pending.subscription.id = id
+			pending.subscription.conn = conn
+			t.subscriptions[id] = pending.subscription
+		}
+	}
+	t.mu.Unlock()
+	if ok {
+		pending.ch <- streamResult{response: &response}
+	}
+}
+
+// drop discards a broken connection and fails every request and
+// subscription waiting on it.
+func (t *streamTransport) drop(conn streamConn, err error) {
+	t.mu.Lock()
+	if t.conn != conn {
+		t.mu.Unlock()
+		return
+	}
+	t.conn = nil
+	pending := t.pending
+	t.pending = make(map[int]*streamPending)
+	subscriptions := t.subscriptions
+	t.subscriptions = make(map[string]*streamSubscription)
+	t.mu.Unlock()
+
+	conn.Close()
+	for _, p := range pending {
+		p.ch <- streamResult{err: err}
+	}
+	for _, subscription := range subscriptions {
+		subscription.fail(err)
+	}
+}
+
+func (t *streamTransport) Close() error {
+	t.mu.Lock()
+	t.closed = true
+	conn := t.conn
+	t.mu.Unlock()
+
+	if conn != nil {
+		t.drop(conn, errTransportClosed)
+	}
+
+	return nil
+}
+
+// streamSubscription queues notifications without bound so that a slow
+// consumer never blocks the transport's read loop.
+type streamSubscription struct {
+	transport *streamTransport
+	id        string
+	conn      streamConn
+
+	notifications chan json.RawMessage
+	errCh         chan error
+
+	mu     sync.Mutex
+	queue  []json.RawMessage
+	signal chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newStreamSubscription(transport *streamTransport) *streamSubscription {
+	s := &streamSubscription{
+		transport:     transport,
+		notifications: make(chan json.RawMessage),
+		errCh:         make(chan error, 1),
+		signal:        make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+	go s.pump()
+
+	return s
+}
+
+func (s *streamSubscription) pump() {
+	defer close(s.notifications)
+	for {
+		s.mu.Lock()
+		queue := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, notification := range queue {
+			select {
+			case s.notifications <- notification:
+			case <-s.done:
+				return
+			}
+		}
+
+		select {
+		case <-s.signal:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *streamSubscription) deliver(notification json.RawMessage) {
+	s.mu.Lock()
+	s.queue = append(s.queue, notification)
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *streamSubscription) fail(err error) {
+	s.once.Do(func() {
+		s.errCh <- err
+		close(s.errCh)
+		close(s.done)
+	})
+}
+
+func (s *streamSubscription) Notifications() <-chan json.RawMessage {
+	return s.notifications
+}
+
+func (s *streamSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *streamSubscription) Unsubscribe() error {
+	t := s.transport
+	t.mu.Lock()
+	id := s.id
+	registered := id != "" && t.subscriptions[id] == s && t.conn == s.conn
+	if registered {
+		delete(t.subscriptions, id)
+	}
+	t.mu.Unlock()
+
+	s.once.Do(func() {
+		close(s.errCh)
+		close(s.done)
+	})
+	if !registered {
+		return nil
+	}
+
+	_, err := t.Request(context.Background(), types.RpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "eth_unsubscribe",
+		Params:  []interface{}{id},
+	})
+	return err
+}
@@ -0,0 +1,401 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// rpcHandler answers a single JSON-RPC request for a stand-in server.
+type rpcHandler func(request types.RpcRequest) types.RpcResponse
+
+func rpcResult(request types.RpcRequest, value interface{}) types.RpcResponse {
+	result, _ := json.Marshal(value)
+	return types.RpcResponse{Jsonrpc: "2.0", Id: request.Id, Result: result}
+}
+
+func rpcFailure(request types.RpcRequest, code int, message string) types.RpcResponse {
+	return types.RpcResponse{Jsonrpc: "2.0", Id: request.Id, Error: &types.RpcError{Code: code, Message: message}}
+}
+
+// echoHandler answers every request with its own params.
+func echoHandler(request types.RpcRequest) types.RpcResponse {
+	return rpcResult(request, request.Params)
+}
+
+// handleRpcMessage answers a request or a batch of requests. Batch responses
+// come back in reverse order, as the spec allows, so callers must match them
+// by id.
+func handleRpcMessage(handler rpcHandler, message []byte) []byte {
+	message = bytes.TrimSpace(message)
+	if len(message) > 0 && message[0] == '[' {
+		var requests []types.RpcRequest
+		if err := json.Unmarshal(message, &requests); err != nil {
+			return nil
+		}
+		responses := make([]types.RpcResponse, 0, len(requests))
+		for i := len(requests) - 1; i >= 0; i-- {
+			responses = append(responses, handler(requests[i]))
+		}
+		data, _ := json.Marshal(responses)
+		return data
+	}
+
+	var request types.RpcRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		return nil
+	}
+	data, _ := json.Marshal(handler(request))
+	return data
+}
+
+func newHttpStandIn(t *testing.T, handler rpcHandler) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(handleRpcMessage(handler, body.Bytes()))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newWebSocketStandIn answers every message concurrently, so responses to
+// concurrent requests arrive out of order.
+func newWebSocketStandIn(t *testing.T, handler rpcHandler) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var writeMu sync.Mutex
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			go func() {
+				response := handleRpcMessage(handler, message)
+				writeMu.Lock()
+				conn.WriteMessage(websocket.TextMessage, response)
+				writeMu.Unlock()
+			}()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func webSocketUrl(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func newIpcStandIn(t *testing.T, handler rpcHandler) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rpc.ipc")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", path, err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				decoder := json.NewDecoder(conn)
+				for {
+					var message json.RawMessage
+					if err := decoder.Decode(&message); err != nil {
+						return
+					}
+					conn.Write(handleRpcMessage(handler, message))
+				}
+			}()
+		}
+	}()
+	return path
+}
+
+func testRequest(id int, params ...interface{}) types.RpcRequest {
+	return types.RpcRequest{Jsonrpc: "2.0", Method: "test_echo", Params: params, Id: id}
+}
+
+func checkEcho(t *testing.T, response *types.RpcResponse, id int, param string) {
+	t.Helper()
+	if response.Id != id {
+		t.Fatalf("got response id %d, want %d", response.Id, id)
+	}
+	var params []string
+	if err := json.Unmarshal(response.Result, &params); err != nil || len(params) != 1 || params[0] != param {
+		t.Fatalf("got result %s, want [%q]", response.Result, param)
+	}
+}
+
+func TestHttpTransportRequest(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		w.Write(handleRpcMessage(echoHandler, body.Bytes()))
+	}))
+	defer server.Close()
+
+	transport := NewHttpTransport(types.NewHttpTransportParams{
+		Url:         server.URL,
+		Headers:     http.Header{"X-Api-Key": []string{"key"}},
+		BearerToken: "token",
+	})
+	defer transport.Close()
+
+	response, err := transport.Request(context.Background(), testRequest(7, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, response, 7, "a")
+
+	if got := header.Get("X-Api-Key"); got != "key" {
+		t.Errorf("got X-Api-Key %q, want %q", got, "key")
+	}
+	if got := header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("got Authorization %q, want %q", got, "Bearer token")
+	}
+	if got := header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("got Content-Type %q, want %q", got, "application/json")
+	}
+}
+
+func TestHttpTransportBatchRequest(t *testing.T) {
+	server := newHttpStandIn(t, echoHandler)
+	transport := NewHttpTransport(types.NewHttpTransportParams{Url: server.URL})
+
+	responses, err := transport.BatchRequest(context.Background(), []types.RpcRequest{testRequest(1, "a"), testRequest(2, "b")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2", len(responses))
+	}
+	checkEcho(t, &responses[0], 2, "b")
+	checkEcho(t, &responses[1], 1, "a")
+}
+
+func TestHttpTransportBatchRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch requests are not supported"}}`))
+	}))
+	defer server.Close()
+	transport := NewHttpTransport(types.NewHttpTransportParams{Url: server.URL})
+
+	_, err := transport.BatchRequest(context.Background(), []types.RpcRequest{testRequest(1, "a")})
+	var rpcErr *types.RpcError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32600 {
+		t.Fatalf("got error %v, want the node's rpc error", err)
+	}
+}
+
+func TestHttpTransportStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	transport := NewHttpTransport(types.NewHttpTransportParams{Url: server.URL})
+
+	_, err := transport.Request(context.Background(), testRequest(1, "a"))
+	var httpErr *types.HttpStatusError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("got error %v, want HttpStatusError", err)
+	}
+	if httpErr.StatusCode != http.StatusTooManyRequests || httpErr.RetryAfter != 2*time.Second {
+		t.Errorf("got status %d retry after %s, want 429 and 2s", httpErr.StatusCode, httpErr.RetryAfter)
+	}
+	if !strings.Contains(string(httpErr.Body), "slow down") {
+		t.Errorf("got body %q", httpErr.Body)
+	}
+}
+
+func TestWebSocketTransportConcurrentRequests(t *testing.T) {
+	server := newWebSocketStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		// Answer later requests first.
+		params := request.Params.([]interface{})
+		if params[0] == "0" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return echoHandler(request)
+	})
+	transport := NewWebSocketTransport(types.NewWebSocketTransportParams{Url: webSocketUrl(server)})
+	defer transport.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Every caller uses the same id; the transport must still route
+			// each response back to its own request.
+			response, err := transport.Request(context.Background(), testRequest(1, string(rune('0'+i))))
+			if err != nil {
+				errs <- err
+				return
+			}
+			var params []string
+			json.Unmarshal(response.Result, &params)
+			if response.Id != 1 || len(params) != 1 || params[0] != string(rune('0'+i)) {
+				errs <- errors.New("response routed to the wrong request: " + string(response.Result))
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestWebSocketTransportBatchRequest(t *testing.T) {
+	server := newWebSocketStandIn(t, echoHandler)
+	transport := NewWebSocketTransport(types.NewWebSocketTransportParams{Url: webSocketUrl(server)})
+	defer transport.Close()
+
+	responses, err := transport.BatchRequest(context.Background(), []types.RpcRequest{testRequest(5, "a"), testRequest(6, "b")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, &responses[0], 5, "a")
+	checkEcho(t, &responses[1], 6, "b")
+}
+
+func TestWebSocketTransportSubscribe(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var request types.RpcRequest
+		if err := conn.ReadJSON(&request); err != nil {
+			return
+		}
+		conn.WriteJSON(rpcResult(request, "0xsub"))
+		for i := 1; i <= 3; i++ {
+			conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "eth_subscription",
+				"params":  map[string]interface{}{"subscription": "0xsub", "result": i},
+			})
+		}
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+	transport := NewWebSocketTransport(types.NewWebSocketTransportParams{Url: webSocketUrl(server)})
+	defer transport.Close()
+
+	subscription, err := transport.Subscribe(context.Background(), []interface{}{"newHeads"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		select {
+		case notification := <-subscription.Notifications():
+			if string(notification) != string(rune('0'+i)) {
+				t.Fatalf("got notification %s, want %d", notification, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for notification %d", i)
+		}
+	}
+}
+
+func TestWebSocketTransportRedialsAfterDrop(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var mu sync.Mutex
+	dials := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		dials++
+		first := dials == 1
+		mu.Unlock()
+
+		_, message, err := conn.ReadMessage()
+		if err != nil || first {
+			// Drop the first connection without answering.
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, handleRpcMessage(echoHandler, message))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+	transport := NewWebSocketTransport(types.NewWebSocketTransportParams{Url: webSocketUrl(server)})
+	defer transport.Close()
+
+	if _, err := transport.Request(context.Background(), testRequest(1, "a")); err == nil {
+		t.Fatal("expected the dropped connection to fail the request")
+	}
+	response, err := transport.Request(context.Background(), testRequest(2, "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, response, 2, "b")
+}
+
+func TestIpcTransport(t *testing.T) {
+	path := newIpcStandIn(t, echoHandler)
+	transport := NewIpcTransport(types.NewIpcTransportParams{Path: path})
+	defer transport.Close()
+
+	response, err := transport.Request(context.Background(), testRequest(3, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, response, 3, "a")
+
+	responses, err := transport.BatchRequest(context.Background(), []types.RpcRequest{testRequest(1, "x"), testRequest(2, "y")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEcho(t, &responses[0], 1, "x")
+	checkEcho(t, &responses[1], 2, "y")
+}
+
+func TestStreamTransportClosed(t *testing.T) {
+	path := newIpcStandIn(t, echoHandler)
+	transport := NewIpcTransport(types.NewIpcTransportParams{Path: path})
+	if _, err := transport.Request(context.Background(), testRequest(1, "a")); err != nil {
+		t.Fatal(err)
+	}
+	transport.Close()
+
+	if _, err := transport.Request(context.Background(), testRequest(2, "b")); !errors.Is(err, errTransportClosed) {
+		t.Fatalf("got error %v, want errTransportClosed", err)
+	}
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+type wsConn struct {
+	conn *websocket.Conn
+}
+
+func (c *wsConn) WriteMessage(data []byte) error {
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *wsConn) ReadMessage() (json.RawMessage, error) {
+	_, data, err := c.conn.ReadMessage()
+	return data, err
+}
+
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
+
+type WebSocketTransport struct {
+	*streamTransport
+}
+
+func NewWebSocketTransport(params types.NewWebSocketTransportParams) *WebSocketTransport {
+	headers := params.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	dial := func(ctx context.Context) (streamConn, error) {
+		if params.DialTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, params.DialTimeout)
+			defer cancel()
+		}
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, params.Url, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		return &wsConn{conn: conn}, nil
+	}
+
+	return &WebSocketTransport{streamTransport: newStreamTransport(dial)}
+}
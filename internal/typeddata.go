@@ -0,0 +1,501 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const eip712DomainType = "EIP712Domain"
+
+var (
+	typedDataArrayPattern = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+	typedDataIntPattern   = regexp.MustCompile(`^(u?)int(\d*)$`)
+	typedDataBytesPattern = regexp.MustCompile(`^bytes(\d+)$`)
+)
+
+// HashTypedData returns the EIP-712 digest of typedData.
+func HashTypedData(typedData types.TypedData) (string, error) {
+	hash, err := hashTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(hash), nil
+}
+
+func hashTypedData(typedData types.TypedData) ([]byte, error) {
+	allTypes, domain := typedDataDomain(typedData)
+
+	domainSeparator, err := hashStruct(allTypes, eip712DomainType, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash domain: %v", err)
+	}
+
+	payload := []byte{0x19, 0x01}
+	payload = append(payload, domainSeparator...)
+	if typedData.PrimaryType != eip712DomainType {
+		messageHash, err := hashStruct(allTypes, typedData.PrimaryType, typedData.Message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash message: %v", err)
+		}
+		payload = append(payload, messageHash...)
+	}
+	return crypto.Keccak256(payload), nil
+}
+
+// typedDataDomain returns the types including EIP712Domain, derived from the
+// domain fields that are set unless declared, and the domain as a message.
+func typedDataDomain(typedData types.TypedData) (map[string][]types.TypedDataField, map[string]interface{}) {
+	domain := typedData.Domain
+	values := map[string]interface{}{}
+	var fields []types.TypedDataField
+	if domain.Name != "" {
+		fields = append(fields, types.TypedDataField{Name: "name", Type: "string"})
+		values["name"] = domain.Name
+	}
+	if domain.Version != "" {
+		fields = append(fields, types.TypedDataField{Name: "version", Type: "string"})
+		values["version"] = domain.Version
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, types.TypedDataField{Name: "chainId", Type: "uint256"})
+		values["chainId"] = domain.ChainId
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, types.TypedDataField{Name: "verifyingContract", Type: "address"})
+		values["verifyingContract"] = domain.VerifyingContract
+	}
+	if domain.Salt != "" {
+		fields = append(fields, types.TypedDataField{Name: "salt", Type: "bytes32"})
+		values["salt"] = domain.Salt
+	}
+
+	allTypes := make(map[string][]types.TypedDataField, len(typedData.Types)+1)
+	for name, typeFields := range typedData.Types {
+		allTypes[name] = typeFields
+	}
+	if _, ok := allTypes[eip712DomainType]; !ok {
+		allTypes[eip712DomainType] = fields
+	}
+	return allTypes, values
+}
+
+func hashStruct(allTypes map[string][]types.TypedDataField, typeName string, data map[string]interface{}) ([]byte, error) {
+	encodedType, err := encodeType(allTypes, typeName)
+	if err != nil {
+		return nil, err
+	}
+	encoded := crypto.Keccak256([]byte(encodedType))
+
+	for _, field := range allTypes[typeName] {
+		value, ok := data[field.Name]
+		if !ok || value == nil {
+			return nil, fmt.Errorf("missing value for %s.%s", typeName, field.Name)
+		}
+		encodedValue, err := encodeTypedValue(allTypes, field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", typeName, field.Name, err)
+		}
+		encoded = append(encoded, encodedValue...)
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// encodeType renders typeName followed by the types it references, sorted by
+// name.
+func encodeType(allTypes map[string][]types.TypedDataField, typeName string) (string, error) {
+	if _, ok := allTypes[typeName]; !ok {
+		return "", fmt.Errorf("unknown type: %s", typeName)
+	}
+
+	dependencies := map[string]bool{}
+	collectTypeDependencies(allTypes, typeName, dependencies)
+	delete(dependencies, typeName)
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	for _, name := range append([]string{typeName}, names...) {
+		builder.WriteString(name)
+		builder.WriteString("(")
+		for i, field := range allTypes[name] {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			builder.WriteString(field.Type)
+			builder.WriteString(" ")
+			builder.WriteString(field.Name)
+		}
+		builder.WriteString(")")
+	}
+	return builder.String(), nil
+}
+
+func collectTypeDependencies(allTypes map[string][]types.TypedDataField, typeName string, dependencies map[string]bool) {
+	if dependencies[typeName] {
+		return
+	}
+	if _, ok := allTypes[typeName]; !ok {
+		return
+	}
+	dependencies[typeName] = true
+	for _, field := range allTypes[typeName] {
+		collectTypeDependencies(allTypes, baseTypedDataType(field.Type), dependencies)
+	}
+}
+
+func baseTypedDataType(typeName string) string {
+	for {
+		match := typedDataArrayPattern.FindStringSubmatch(typeName)
+		if match == nil {
+			return typeName
+		}
+		typeName = match[1]
+	}
+}
+
+func encodeTypedValue(allTypes map[string][]types.TypedDataField, typeName string, value interface{}) ([]byte, error) {
+	if match := typedDataArrayPattern.FindStringSubmatch(typeName); match != nil {
+		items, err := typedDataArrayItems(value)
+		if err != nil {
+			return nil, err
+		}
+		if match[2] != "" {
+			length, _ := strconv.Atoi(match[2])
+			if len(items) != length {
+				return nil, fmt.Errorf("expected %d items for %s, got %d", length, typeName, len(items))
+			}
+		}
+		var encoded []byte
+		for _, item := range items {
+			encodedItem, err := encodeTypedValue(allTypes, match[1], item)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, encodedItem...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if _, ok := allTypes[typeName]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected map[string]interface{} for %s, got %T", typeName, value)
+		}
+		return hashStruct(allTypes, typeName, data)
+	}
+
+	switch typeName {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return crypto.Keccak256([]byte(str)), nil
+	case "bytes":
+		data, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(data), nil
+	case "address":
+		var address common.Address
+		switch v := value.(type) {
+		case common.Address:
+			address = v
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, fmt.Errorf("invalid address: %s", v)
+			}
+			address = common.HexToAddress(v)
+		default:
+			return nil, fmt.Errorf("expected address, got %T", value)
+		}
+		return common.LeftPadBytes(address.Bytes(), 32), nil
+	case "bool":
+		var flag bool
+		switch v := value.(type) {
+		case bool:
+			flag = v
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool: %s", v)
+			}
+			flag = parsed
+		default:
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		encoded := make([]byte, 32)
+		if flag {
+			encoded[31] = 1
+		}
+		return encoded, nil
+	}
+
+	if match := typedDataBytesPattern.FindStringSubmatch(typeName); match != nil {
+		size, _ := strconv.Atoi(match[1])
+		if size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type: %s", typeName)
+		}
+		data, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != size {
+			return nil, fmt.Errorf("expected %d bytes for %s, got %d", size, typeName, len(data))
+		}
+		return common.RightPadBytes(data, 32), nil
+	}
+
+	if match := typedDataIntPattern.FindStringSubmatch(typeName); match != nil {
+		bits := 256
+		if match[2] != "" {
+			bits, _ = strconv.Atoi(match[2])
+		}
+		if bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("invalid type: %s", typeName)
+		}
+		number, err := typedDataInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if match[1] == "u" {
+			if number.Sign() < 0 || number.BitLen() > bits {
+				return nil, fmt.Errorf("%s out of range for %s", number, typeName)
+			}
+			return math.U256Bytes(number), nil
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%s out of range for %s", number, typeName)
+		}
+		return math.U256Bytes(new(big.Int).Set(number)), nil
+	}
+
+	return nil, fmt.Errorf("unknown type: %s", typeName)
+}
+
+func typedDataArrayItems(value interface{}) ([]interface{}, error) {
+	if items, ok := value.([]interface{}); ok {
+		return items, nil
+	}
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected array, got %T", value)
+	}
+	items := make([]interface{}, reflected.Len())
+	for i := range items {
+		items[i] = reflected.Index(i).Interface()
+	}
+	return items, nil
+}
+
+func typedDataBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case common.Hash:
+		return v.Bytes(), nil
+	case string:
+		data, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex bytes %q: %v", v, err)
+		}
+		return data, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Array && reflected.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, reflected.Len())
+		reflect.Copy(reflect.ValueOf(data), reflected)
+		return data, nil
+	}
+	return nil, fmt.Errorf("expected bytes, got %T", value)
+}
+
+func typedDataInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case json.Number:
+		return parseTypedDataInt(string(v))
+	case string:
+		return parseTypedDataInt(v)
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("expected integer, got %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(reflected.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(reflected.Uint()), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", value)
+}
+
+func parseTypedDataInt(value string) (*big.Int, error) {
+	negative := strings.HasPrefix(value, "-")
+	number, ok := new(big.Int).SetString(strings.TrimPrefix(value, "-"), 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %s", value)
+	}
+	if negative {
+		number.Neg(number)
+	}
+	return number, nil
+}
+
+// typedDataJson returns typedData in the JSON form signing services expect,
+// with integers as decimal strings and bytes as hex.
+func typedDataJson(typedData types.TypedData) (map[string]interface{}, error) {
+	allTypes, domain := typedDataDomain(typedData)
+
+	jsonDomain, err := typedDataJsonValue(allTypes, eip712DomainType, domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain: %v", err)
+	}
+	message := interface{}(map[string]interface{}{})
+	if typedData.PrimaryType != eip712DomainType {
+		message, err = typedDataJsonValue(allTypes, typedData.PrimaryType, typedData.Message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message: %v", err)
+		}
+	}
+
+	return map[string]interface{}{
+		"types":       allTypes,
+		"primaryType": typedData.PrimaryType,
+		"domain":      jsonDomain,
+		"message":     message,
+	}, nil
+}
+
+func typedDataJsonValue(allTypes map[string][]types.TypedDataField, typeName string, value interface{}) (interface{}, error) {
+	if match := typedDataArrayPattern.FindStringSubmatch(typeName); match != nil {
+		items, err := typedDataArrayItems(value)
+		if err != nil {
+			return nil, err
+		}
+		jsonItems := make([]interface{}, len(items))
+		for i, item := range items {
+			jsonItems[i], err = typedDataJsonValue(allTypes, match[1], item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return jsonItems, nil
+	}
+
+	if fields, ok := allTypes[typeName]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected map[string]interface{} for %s, got %T", typeName, value)
+		}
+		jsonData := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			fieldValue, ok := data[field.Name]
+			if !ok || fieldValue == nil {
+				return nil, fmt.Errorf("missing value for %s.%s", typeName, field.Name)
+			}
+			jsonValue, err := typedDataJsonValue(allTypes, field.Type, fieldValue)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", typeName, field.Name, err)
+			}
+			jsonData[field.Name] = jsonValue
+		}
+		return jsonData, nil
+	}
+
+	switch {
+	case typeName == "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return str, nil
+	case typeName == "address":
+		switch v := value.(type) {
+		case common.Address:
+			return v.Hex(), nil
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, fmt.Errorf("invalid address: %s", v)
+			}
+			return common.HexToAddress(v).Hex(), nil
+		}
+		return nil, fmt.Errorf("expected address, got %T", value)
+	case typeName == "bool":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			flag, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool: %s", v)
+			}
+			return flag, nil
+		}
+		return nil, fmt.Errorf("expected bool, got %T", value)
+	case typeName == "bytes" || typedDataBytesPattern.MatchString(typeName):
+		data, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return hexutil.Encode(data), nil
+	case typedDataIntPattern.MatchString(typeName):
+		number, err := typedDataInt(value)
+		if err != nil {
+			return nil, err
+		}
+		return number.String(), nil
+	}
+
+	return nil, fmt.Errorf("unknown type: %s", typeName)
+}
+
+func RecoverTypedDataAddress(typedData types.TypedData, signature string) (string, error) {
+	hash, err := hashTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+	return recoverAddress(hash, signature)
+}
+
+func VerifyTypedData(address string, typedData types.TypedData, signature string) (bool, error) {
+	signer, err := RecoverTypedDataAddress(typedData, signature)
+	if err != nil {
+		return false, err
+	}
+	return common.HexToAddress(signer) == common.HexToAddress(address), nil
+}
+
+// SignTypedData signs the EIP-712 digest of typedData with account.
+func SignTypedData(account *types.Account, typedData types.TypedData) (string, error) {
+	hash, err := hashTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+	return account.SignHash(hash)
+}
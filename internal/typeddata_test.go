@@ -0,0 +1,332 @@
+package internal
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// mailTypedData is the example of the EIP-712 specification.
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+const (
+	mailHash      = "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+	mailSignature = "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+)
+
+func parseTypedData(t *testing.T, data string) types.TypedData {
+	t.Helper()
+	var typedData types.TypedData
+	if err := json.Unmarshal([]byte(data), &typedData); err != nil {
+		t.Fatal(err)
+	}
+	return typedData
+}
+
+// cowAccount holds the key of the specification's example, keccak256("cow").
+func cowAccount(t *testing.T) *types.Account {
+	t.Helper()
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Account{Address: crypto.PubkeyToAddress(key.PublicKey).Hex(), PrivateKey: key}
+}
+
+func TestHashTypedDataMail(t *testing.T) {
+	hash, err := HashTypedData(parseTypedData(t, mailTypedData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != mailHash {
+		t.Errorf("got hash %s, want %s", hash, mailHash)
+	}
+
+	// Go values and a domain derived from its set fields hash the same.
+	hash, err = HashTypedData(types.TypedData{
+		Types: map[string][]types.TypedDataField{
+			"Person": {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+			"Mail":   {{Name: "from", Type: "Person"}, {Name: "to", Type: "Person"}, {Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain: types.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainId:           big.NewInt(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: map[string]interface{}{
+			"from":     map[string]interface{}{"name": "Cow", "wallet": common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")},
+			"contents": "Hello, Bob!",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != mailHash {
+		t.Errorf("got hash %s from Go values, want %s", hash, mailHash)
+	}
+}
+
+func TestSignTypedDataMail(t *testing.T) {
+	account := cowAccount(t)
+	if account.Address != "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" {
+		t.Fatalf("got address %s for the example key", account.Address)
+	}
+	typedData := parseTypedData(t, mailTypedData)
+
+	signature, err := SignTypedData(account, typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signature != mailSignature {
+		t.Errorf("got signature %s, want %s", signature, mailSignature)
+	}
+
+	signer, err := RecoverTypedDataAddress(typedData, mailSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer != account.Address {
+		t.Errorf("recovered %s, want %s", signer, account.Address)
+	}
+	valid, err := VerifyTypedData("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB", typedData, mailSignature)
+	if err != nil || valid {
+		t.Errorf("got %v, %v verifying against another address, want false", valid, err)
+	}
+
+	typedData.Message["contents"] = "Hello, Alice!"
+	valid, err = VerifyTypedData(account.Address, typedData, mailSignature)
+	if err != nil || valid {
+		t.Errorf("got %v, %v verifying a changed message, want false", valid, err)
+	}
+}
+
+// keccakWords hashes the concatenation of words, as encodeData does.
+func keccakWords(words ...[]byte) []byte {
+	return crypto.Keccak256(words...)
+}
+
+func addressWord(address string) []byte {
+	return common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)
+}
+
+func uintWord(value int64) []byte {
+	return common.LeftPadBytes(big.NewInt(value).Bytes(), 32)
+}
+
+// typedDigest builds the final digest from a domain with only a name and a
+// message struct hash.
+func typedDigest(domainName string, messageHash []byte) string {
+	domainSeparator := keccakWords(crypto.Keccak256([]byte("EIP712Domain(string name)")), crypto.Keccak256([]byte(domainName)))
+	return hexutil.Encode(crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash))
+}
+
+func TestHashTypedDataArrays(t *testing.T) {
+	typedData := types.TypedData{
+		Types: map[string][]types.TypedDataField{
+			"Person": {{Name: "name", Type: "string"}, {Name: "wallets", Type: "address[]"}},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person[]"},
+				{Name: "scores", Type: "uint8[2]"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain:      types.TypedDataDomain{Name: "Arrays"},
+		Message: map[string]interface{}{
+			"from": map[string]interface{}{
+				"name":    "Cow",
+				"wallets": []string{"0x00000000000000000000000000000000000000aa"},
+			},
+			"to": []interface{}{
+				map[string]interface{}{"name": "Bob", "wallets": []interface{}{}},
+				map[string]interface{}{
+					"name":    "Alice",
+					"wallets": []string{"0x00000000000000000000000000000000000000bb", "0x00000000000000000000000000000000000000cc"},
+				},
+			},
+			"scores": []int{1, 2},
+		},
+	}
+
+	personType := crypto.Keccak256([]byte("Person(string name,address[] wallets)"))
+	person := func(name string, wallets ...string) []byte {
+		var encoded [][]byte
+		for _, wallet := range wallets {
+			encoded = append(encoded, addressWord(wallet))
+		}
+		return keccakWords(personType, crypto.Keccak256([]byte(name)), crypto.Keccak256(encoded...))
+	}
+	mailType := crypto.Keccak256([]byte("Mail(Person from,Person[] to,uint8[2] scores)Person(string name,address[] wallets)"))
+	message := keccakWords(
+		mailType,
+		person("Cow", "0x00000000000000000000000000000000000000aa"),
+		crypto.Keccak256(person("Bob"), person("Alice", "0x00000000000000000000000000000000000000bb", "0x00000000000000000000000000000000000000cc")),
+		crypto.Keccak256(uintWord(1), uintWord(2)),
+	)
+
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := typedDigest("Arrays", message); hash != want {
+		t.Errorf("got hash %s, want %s", hash, want)
+	}
+}
+
+func TestHashTypedDataNestedStructs(t *testing.T) {
+	typedData := types.TypedData{
+		Types: map[string][]types.TypedDataField{
+			"Order":  {{Name: "maker", Type: "Person"}, {Name: "item", Type: "Item"}},
+			"Item":   {{Name: "name", Type: "string"}, {Name: "meta", Type: "Meta"}},
+			"Meta":   {{Name: "tag", Type: "bytes32"}, {Name: "amount", Type: "uint256"}, {Name: "listed", Type: "bool"}},
+			"Person": {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+		},
+		PrimaryType: "Order",
+		Domain:      types.TypedDataDomain{Name: "Nested"},
+		Message: map[string]interface{}{
+			"maker": map[string]interface{}{"name": "Cow", "wallet": "0x00000000000000000000000000000000000000aa"},
+			"item": map[string]interface{}{
+				"name": "Hammer",
+				"meta": map[string]interface{}{
+					"tag":    common.Hash{1},
+					"amount": "1000",
+					"listed": true,
+				},
+			},
+		},
+	}
+
+	// Referenced types follow the primary type sorted by name, however deep
+	// they are nested.
+	metaType := crypto.Keccak256([]byte("Meta(bytes32 tag,uint256 amount,bool listed)"))
+	itemType := crypto.Keccak256([]byte("Item(string name,Meta meta)Meta(bytes32 tag,uint256 amount,bool listed)"))
+	personType := crypto.Keccak256([]byte("Person(string name,address wallet)"))
+	orderType := crypto.Keccak256([]byte("Order(Person maker,Item item)Item(string name,Meta meta)Meta(bytes32 tag,uint256 amount,bool listed)Person(string name,address wallet)"))
+	meta := keccakWords(metaType, common.Hash{1}.Bytes(), uintWord(1000), uintWord(1))
+	item := keccakWords(itemType, crypto.Keccak256([]byte("Hammer")), meta)
+	maker := keccakWords(personType, crypto.Keccak256([]byte("Cow")), addressWord("0x00000000000000000000000000000000000000aa"))
+	message := keccakWords(orderType, maker, item)
+
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := typedDigest("Nested", message); hash != want {
+		t.Errorf("got hash %s, want %s", hash, want)
+	}
+}
+
+func TestHashTypedDataErrors(t *testing.T) {
+	person := []types.TypedDataField{{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}}
+	tests := []struct {
+		name      string
+		typedData types.TypedData
+		want      string
+	}{
+		{
+			name: "unknown primary type",
+			typedData: types.TypedData{
+				Types:       map[string][]types.TypedDataField{"Person": person},
+				PrimaryType: "Mail",
+				Message:     map[string]interface{}{},
+			},
+			want: "unknown type: Mail",
+		},
+		{
+			name: "unknown field type",
+			typedData: types.TypedData{
+				Types:       map[string][]types.TypedDataField{"Mail": {{Name: "from", Type: "Human"}}},
+				PrimaryType: "Mail",
+				Message:     map[string]interface{}{"from": map[string]interface{}{"name": "Cow"}},
+			},
+			want: "unknown type: Human",
+		},
+		{
+			name: "missing value",
+			typedData: types.TypedData{
+				Types:       map[string][]types.TypedDataField{"Person": person},
+				PrimaryType: "Person",
+				Message:     map[string]interface{}{"name": "Cow"},
+			},
+			want: "missing value for Person.wallet",
+		},
+		{
+			name: "missing nested value",
+			typedData: types.TypedData{
+				Types: map[string][]types.TypedDataField{
+					"Person": person,
+					"Mail":   {{Name: "from", Type: "Person"}},
+				},
+				PrimaryType: "Mail",
+				Message:     map[string]interface{}{"from": map[string]interface{}{"wallet": "0x00000000000000000000000000000000000000aa"}},
+			},
+			want: "missing value for Person.name",
+		},
+		{
+			name: "fixed array length",
+			typedData: types.TypedData{
+				Types:       map[string][]types.TypedDataField{"Scores": {{Name: "values", Type: "uint8[2]"}}},
+				PrimaryType: "Scores",
+				Message:     map[string]interface{}{"values": []int{1, 2, 3}},
+			},
+			want: "expected 2 items",
+		},
+		{
+			name: "integer out of range",
+			typedData: types.TypedData{
+				Types:       map[string][]types.TypedDataField{"Scores": {{Name: "value", Type: "uint8"}}},
+				PrimaryType: "Scores",
+				Message:     map[string]interface{}{"value": 256},
+			},
+			want: "out of range for uint8",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := HashTypedData(test.typedData)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
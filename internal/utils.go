@@ -22,6 +22,9 @@ func HexToBigInt(hexStr string) *big.Int {
 }
 
 func HexToUint64(hexStr string) uint64 {
+	if hexStr == "" {
+		return 0
+	}
 	value, err := strconv.ParseUint(hexStr, 0, 64)
 	if err != nil {
 		panic(err)
@@ -29,18 +32,120 @@ func HexToUint64(hexStr string) uint64 {
 	return value
 }
 
+func hexToOptionalBigInt(hexStr string) *big.Int {
+	if hexStr == "" {
+		return nil
+	}
+	return HexToBigInt(hexStr)
+}
+
+func hexesToBigInts(hexStrs []string) []*big.Int {
+	if hexStrs == nil {
+		return nil
+	}
+	values := make([]*big.Int, 0, len(hexStrs))
+	for _, hexStr := range hexStrs {
+		values = append(values, HexToBigInt(hexStr))
+	}
+	return values
+}
+
+func ConvertRawFeeHistory(rawFeeHistory types.RawFeeHistory) types.FeeHistory {
+	feeHistory := types.FeeHistory{
+		OldestBlock:       HexToBigInt(rawFeeHistory.OldestBlock),
+		BaseFeePerGas:     hexesToBigInts(rawFeeHistory.BaseFeePerGas),
+		GasUsedRatio:      rawFeeHistory.GasUsedRatio,
+		BaseFeePerBlobGas: hexesToBigInts(rawFeeHistory.BaseFeePerBlobGas),
+		BlobGasUsedRatio:  rawFeeHistory.BlobGasUsedRatio,
+	}
+	for _, blockRewards := range rawFeeHistory.Reward {
+		feeHistory.Reward = append(feeHistory.Reward, hexesToBigInts(blockRewards))
+	}
+	return feeHistory
+}
+
 func ConvertRawTransaction(rawTx types.RawTransaction) types.Transaction {
 	return types.Transaction{
-		Hash:     rawTx.Hash,
-		From:     rawTx.From,
-		To:       rawTx.To,
-		Nonce:    rawTx.Nonce,
-		Value:    HexToBigInt(rawTx.Value),
-		GasPrice: HexToBigInt(rawTx.GasPrice),
-		Gas:      HexToUint64(rawTx.Gas),
+		Hash:                 rawTx.Hash,
+		BlockHash:            rawTx.BlockHash,
+		BlockNumber:          hexToOptionalBigInt(rawTx.BlockNumber),
+		From:                 rawTx.From,
+		To:                   rawTx.To,
+		Nonce:                rawTx.Nonce,
+		Value:                HexToBigInt(rawTx.Value),
+		GasPrice:             HexToBigInt(rawTx.GasPrice),
+		MaxFeePerGas:         hexToOptionalBigInt(rawTx.MaxFeePerGas),
+		MaxPriorityFeePerGas: hexToOptionalBigInt(rawTx.MaxPriorityFeePerGas),
+		Gas:                  HexToUint64(rawTx.Gas),
+		Input:                rawTx.Input,
+		Type:                 HexToUint64(rawTx.Type),
+		ChainId:              hexToOptionalBigInt(rawTx.ChainId),
+		TransactionIndex:     HexToUint64(rawTx.TransactionIndex),
+		AccessList:           rawTx.AccessList,
+		AuthorizationList:    convertRawAuthorizations(rawTx.AuthorizationList),
 	}
 }
 
+func convertRawAuthorizations(rawAuthorizations []types.RawSignedAuthorization) []types.SignedAuthorization {
+	if rawAuthorizations == nil {
+		return nil
+	}
+	authorizations := make([]types.SignedAuthorization, 0, len(rawAuthorizations))
+	for _, rawAuthorization := range rawAuthorizations {
+		// Some nodes report the parity as v.
+		yParity := rawAuthorization.YParity
+		if yParity == "" {
+			yParity = rawAuthorization.V
+		}
+		authorizations = append(authorizations, types.SignedAuthorization{
+			ChainId: HexToBigInt(rawAuthorization.ChainId),
+			Address: rawAuthorization.Address,
+			Nonce:   HexToUint64(rawAuthorization.Nonce),
+			YParity: uint8(HexToUint64(yParity)),
+			R:       HexToBigInt(rawAuthorization.R),
+			S:       HexToBigInt(rawAuthorization.S),
+		})
+	}
+	return authorizations
+}
+
+func ConvertRawLog(rawLog types.RawLog) types.Log {
+	return types.Log{
+		Address:          rawLog.Address,
+		Topics:           rawLog.Topics,
+		Data:             rawLog.Data,
+		BlockNumber:      hexToOptionalBigInt(rawLog.BlockNumber),
+		BlockHash:        rawLog.BlockHash,
+		TransactionHash:  rawLog.TransactionHash,
+		TransactionIndex: HexToUint64(rawLog.TransactionIndex),
+		LogIndex:         HexToUint64(rawLog.LogIndex),
+		Removed:          rawLog.Removed,
+	}
+}
+
+func ConvertRawReceipt(rawReceipt types.RawReceipt) types.Receipt {
+	receipt := types.Receipt{
+		TransactionHash:   rawReceipt.TransactionHash,
+		TransactionIndex:  HexToUint64(rawReceipt.TransactionIndex),
+		BlockHash:         rawReceipt.BlockHash,
+		BlockNumber:       hexToOptionalBigInt(rawReceipt.BlockNumber),
+		From:              rawReceipt.From,
+		To:                rawReceipt.To,
+		CumulativeGasUsed: HexToUint64(rawReceipt.CumulativeGasUsed),
+		GasUsed:           HexToUint64(rawReceipt.GasUsed),
+		EffectiveGasPrice: hexToOptionalBigInt(rawReceipt.EffectiveGasPrice),
+		ContractAddress:   rawReceipt.ContractAddress,
+		LogsBloom:         rawReceipt.LogsBloom,
+		Status:            HexToUint64(rawReceipt.Status),
+		Type:              HexToUint64(rawReceipt.Type),
+	}
+	for _, rawLog := range rawReceipt.Logs {
+		receipt.Logs = append(receipt.Logs, ConvertRawLog(rawLog))
+	}
+
+	return receipt
+}
+
 func GeneratePrivateKeyEcdsa() (*ecdsa.PrivateKey, error) {
 	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
 	if err != nil {
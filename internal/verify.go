@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const erc1271Abi = `[{"type":"function","name":"isValidSignature","stateMutability":"view","inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],"outputs":[{"name":"magicValue","type":"bytes4"}]}]`
+
+var (
+	erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+	// erc6492MagicSuffix ends every ERC-6492 wrapped signature.
+	erc6492MagicSuffix = common.FromHex("0x6492649264926492649264926492649264926492649264926492649264926492")
+	erc6492Arguments   = abi.Arguments{
+		{Type: mustAbiType("address")},
+		{Type: mustAbiType("bytes")},
+		{Type: mustAbiType("bytes")},
+	}
+	// deploylessValidatorCode is the init code of a throwaway contract whose
+	// constructor runs two calls laid out after it in ABI words,
+	// (to1, len1, to2, len2, data1, data2), ignores the outcome of the first
+	// and returns the result of the second. It lets one eth_call deploy a
+	// counterfactual wallet through its factory and then ask it to validate
+	// a signature.
+	deploylessValidatorCode = common.FromHex("0x60413803604160003960006000602051608060006000515af150" +
+		"6000600060605160205160800160006040515af115603c57" +
+		"3d600060003e3d6000f35b600080fd")
+)
+
+func mustAbiType(typeName string) abi.Type {
+	abiType, err := abi.NewType(typeName, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abiType
+}
+
+// VerifySignature checks EOA signatures locally and asks contract accounts
+// through ERC-1271, deploying ERC-6492 counterfactual wallets within the
+// call.
+func (c *RpcClient) VerifySignature(ctx context.Context, params types.VerifySignatureParams) (bool, error) {
+	hash, err := signedHash(params)
+	if err != nil {
+		return false, err
+	}
+	signature, err := hexutil.Decode(params.Signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode signature: %v", err)
+	}
+
+	if bytes.HasSuffix(signature, erc6492MagicSuffix) {
+		values, err := erc6492Arguments.Unpack(signature[:len(signature)-len(erc6492MagicSuffix)])
+		if err != nil {
+			return false, fmt.Errorf("failed to decode ERC-6492 signature: %v", err)
+		}
+		factory := values[0].(common.Address)
+		factoryCalldata := values[1].([]byte)
+		innerSignature := values[2].([]byte)
+
+		code, err := c.getCode(ctx, params.Address)
+		if err != nil {
+			return false, err
+		}
+		if len(code) == 0 {
+			return c.verifyCounterfactualSignature(ctx, params.Address, hash, factory, factoryCalldata, innerSignature)
+		}
+		return c.verifyContractSignature(ctx, params.Address, hash, innerSignature)
+	}
+
+	if len(signature) == 65 {
+		signer, err := recoverAddress(hash, params.Signature)
+		if err == nil && common.HexToAddress(signer) == common.HexToAddress(params.Address) {
+			return true, nil
+		}
+	}
+
+	code, err := c.getCode(ctx, params.Address)
+	if err != nil {
+		return false, err
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+	return c.verifyContractSignature(ctx, params.Address, hash, signature)
+}
+
+func signedHash(params types.VerifySignatureParams) ([]byte, error) {
+	switch {
+	case params.TypedData != nil:
+		return hashTypedData(*params.TypedData)
+	case params.Message != nil:
+		return accounts.TextHash(params.Message), nil
+	case params.Hash != "":
+		hash, err := hexutil.Decode(params.Hash)
+		if err != nil || len(hash) != 32 {
+			return nil, fmt.Errorf("invalid hash: %s", params.Hash)
+		}
+		return hash, nil
+	default:
+		return nil, fmt.Errorf("one of Hash, Message or TypedData is required")
+	}
+}
+
+func (c *RpcClient) verifyContractSignature(ctx context.Context, address string, hash []byte, signature []byte) (bool, error) {
+	result, err := c.ReadContract(ctx, types.ReadContractParams{
+		Address:      address,
+		Abi:          erc1271Abi,
+		FunctionName: "isValidSignature",
+		Args:         []interface{}{common.BytesToHash(hash), signature},
+	})
+	if err != nil {
+		// A reverting or non-conforming wallet rejects the signature.
+		var revertErr *types.ContractRevertError
+		if errors.As(err, &revertErr) || errors.Is(err, types.ErrUnpackResult) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var magicValue [4]byte
+	if err := json.Unmarshal(result, &magicValue); err != nil {
+		return false, fmt.Errorf("failed to unmarshal magic value: %v", err)
+	}
+	return magicValue == erc1271MagicValue, nil
+}
+
+func (c *RpcClient) verifyCounterfactualSignature(ctx context.Context, address string, hash []byte, factory common.Address, factoryCalldata []byte, signature []byte) (bool, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(erc1271Abi))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+	validateCalldata, err := parsedABI.Pack("isValidSignature", common.BytesToHash(hash), signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to pack arguments: %v", err)
+	}
+
+	data := append([]byte{}, deploylessValidatorCode...)
+	data = append(data, common.LeftPadBytes(factory.Bytes(), 32)...)
+	data = append(data, abiWord(len(factoryCalldata))...)
+	data = append(data, common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)...)
+	data = append(data, abiWord(len(validateCalldata))...)
+	data = append(data, factoryCalldata...)
+	data = append(data, validateCalldata...)
+
+	result, err := c.Call(ctx, "eth_call", []interface{}{
+		map[string]interface{}{"data": hexutil.Encode(data)},
+		"latest",
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrExecutionReverted) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to validate signature: %w", err)
+	}
+
+	var returnData string
+	if err := json.Unmarshal(result, &returnData); err != nil {
+		return false, fmt.Errorf("failed to unmarshal validation result: %v", err)
+	}
+	output := common.FromHex(returnData)
+	return len(output) >= 4 && bytes.Equal(output[:4], erc1271MagicValue[:]), nil
+}
+
+func abiWord(value int) []byte {
+	return common.LeftPadBytes(big.NewInt(int64(value)).Bytes(), 32)
+}
+
+func (c *RpcClient) getCode(ctx context.Context, address string) ([]byte, error) {
+	result, err := c.Call(ctx, "eth_getCode", []interface{}{address, "latest"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code: %w", err)
+	}
+
+	var code string
+	if err := json.Unmarshal(result, &code); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal code: %v", err)
+	}
+	return common.FromHex(code), nil
+}
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// newWalletStandIn answers eth_getCode with some code and eth_call with
+// callResult, as a deployed ERC-1271 wallet would.
+func newWalletStandIn(t *testing.T, callResult string) *RpcClient {
+	t.Helper()
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		switch request.Method {
+		case "eth_getCode":
+			return rpcResult(request, "0x6000")
+		case "eth_call":
+			return rpcResult(request, callResult)
+		}
+		return rpcFailure(request, -32601, "unexpected call to "+request.Method)
+	})
+	return NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL})
+}
+
+func TestVerifyContractSignature(t *testing.T) {
+	wallet := "0x000000000000000000000000000000000000dEaD"
+	params := types.VerifySignatureParams{
+		Address:   wallet,
+		Hash:      hexutil.Encode(common.Hash{1}.Bytes()),
+		Signature: "0x1234",
+	}
+	tests := map[string]struct {
+		callResult string
+		want       bool
+	}{
+		"magic value":  {hexutil.Encode(common.RightPadBytes(erc1271MagicValue[:], 32)), true},
+		"other value":  {hexutil.Encode(make([]byte, 32)), false},
+		"empty result": {"0x", false},
+		"short result": {"0x1626ba7e", false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			valid, err := newWalletStandIn(t, test.callResult).VerifySignature(context.Background(), params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if valid != test.want {
+				t.Errorf("got %v, want %v", valid, test.want)
+			}
+		})
+	}
+}
+
+func TestReadContractUnpackError(t *testing.T) {
+	c := newWalletStandIn(t, "0x")
+	_, err := c.ReadContract(context.Background(), types.ReadContractParams{
+		Address:      "0x000000000000000000000000000000000000dEaD",
+		Abi:          erc1271Abi,
+		FunctionName: "isValidSignature",
+		Args:         []interface{}{common.Hash{}, []byte{}},
+	})
+	if !errors.Is(err, types.ErrUnpackResult) {
+		t.Fatalf("got error %v, want ErrUnpackResult", err)
+	}
+}
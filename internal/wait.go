@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const (
+	defaultPollingInterval = 4 * time.Second
+	// droppedAfterMisses is how many consecutive polls a previously seen
+	// transaction may be missing from the node before it is considered dropped.
+	droppedAfterMisses = 3
+)
+
+func (c *RpcClient) WaitForTransactionReceipt(ctx context.Context, params types.WaitForTransactionReceiptParams) (*types.Receipt, error) {
+	confirmations := params.Confirmations
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	pollingInterval := params.PollingInterval
+	if pollingInterval <= 0 {
+		pollingInterval = defaultPollingInterval
+	}
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
+
+	startBlock, err := c.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := params.Hash
+	var tx *types.Transaction
+	var replacement *types.TransactionReplacement
+	misses := 0
+
+	for {
+		receipt, err := c.GetTransactionReceipt(ctx, hash)
+		switch {
+		case err == nil:
+			blockNumber, err := c.GetBlockNumber(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if receipt.BlockNumber != nil && blockNumber+1 >= receipt.BlockNumber.Uint64()+confirmations {
+				if replacement != nil && params.OnReplaced != nil {
+					replacement.Receipt = receipt
+					params.OnReplaced(*replacement)
+				}
+				return receipt, nil
+			}
+		case errors.Is(err, types.ErrTransactionReceiptNotFound):
+			if replacement != nil {
+				break
+			}
+
+			found, err := c.GetTransaction(ctx, hash)
+			if err == nil {
+				tx = found
+				misses = 0
+				break
+			}
+			if !errors.Is(err, types.ErrTransactionNotFound) {
+				return nil, err
+			}
+			if tx == nil {
+				// Not propagated to this node yet.
+				break
+			}
+
+			replacement, err = c.findReplacement(ctx, tx, startBlock)
+			if err != nil {
+				return nil, err
+			}
+			if replacement != nil {
+				hash = replacement.Transaction.Hash
+				break
+			}
+
+			misses++
+			if misses >= droppedAfterMisses {
+				return nil, fmt.Errorf("%w: %s", types.ErrTransactionDropped, params.Hash)
+			}
+		default:
+			return nil, err
+		}
+
+		timer := time.NewTimer(pollingInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, &types.TimeoutError{Method: "WaitForTransactionReceipt", Err: ctx.Err()}
+			}
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// findReplacement looks for a mined transaction from the same sender with the
+// same nonce once the sender's nonce has moved past tx.
+func (c *RpcClient) findReplacement(ctx context.Context, tx *types.Transaction, fromBlock uint64) (*types.TransactionReplacement, error) {
+	nonce, err := c.GetTransactionCount(ctx, tx.From)
+	if err != nil {
+		return nil, err
+	}
+	txNonce := HexToUint64(tx.Nonce)
+	if nonce <= txNonce {
+		return nil, nil
+	}
+
+	toBlock, err := c.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for number := toBlock; number >= fromBlock; number-- {
+		block, err := c.GetBlock(ctx, types.GetBlockParams{BlockNumber: new(big.Int).SetUint64(number)})
+		if err != nil {
+			return nil, err
+		}
+		for i := range block.Transactions {
+			candidate := &block.Transactions[i]
+			if !strings.EqualFold(candidate.From, tx.From) || HexToUint64(candidate.Nonce) != txNonce {
+				continue
+			}
+			if strings.EqualFold(candidate.Hash, tx.Hash) {
+				// Mined after all; the receipt will show up on the next poll.
+				return nil, nil
+			}
+
+			return &types.TransactionReplacement{
+				Reason:              replacementReason(tx, candidate),
+				ReplacedTransaction: tx,
+				Transaction:         candidate,
+			}, nil
+		}
+		if number == 0 {
+			break
+		}
+	}
+
+	return nil, nil
+}
+
+func replacementReason(original, replacement *types.Transaction) string {
+	isZeroValue := replacement.Value == nil || replacement.Value.Sign() == 0
+	isEmptyInput := replacement.Input == "" || replacement.Input == "0x"
+	if strings.EqualFold(replacement.To, replacement.From) && isZeroValue && isEmptyInput {
+		return "cancelled"
+	}
+
+	sameValue := original.Value != nil && replacement.Value != nil && original.Value.Cmp(replacement.Value) == 0
+	if strings.EqualFold(original.To, replacement.To) && sameValue && original.Input == replacement.Input {
+		return "repriced"
+	}
+
+	return "replaced"
+}
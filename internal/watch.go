@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+var errSubscriptionsNotSupported = errors.New("transport does not support subscriptions")
+
+func (c *RpcClient) subscribe(ctx context.Context, params ...interface{}) (types.Subscription, error) {
+	transport, ok := c.transport.(types.SubscriptionTransport)
+	if !ok {
+		return nil, errSubscriptionsNotSupported
+	}
+
+	return transport.Subscribe(ctx, params)
+}
+
+// watchHeads calls onHead with the number of every new chain head, using
+// newHeads notifications when the transport supports them and polling
+// eth_blockNumber otherwise. It returns when ctx is cancelled.
+func (c *RpcClient) watchHeads(ctx context.Context, poll bool, interval time.Duration, onHead func(number uint64), onError func(err error)) {
+	if interval <= 0 {
+		interval = defaultPollingInterval
+	}
+	if _, ok := c.transport.(types.SubscriptionTransport); !ok {
+		poll = true
+	}
+
+	for ctx.Err() == nil {
+		if !poll {
+			subscription, err := c.subscribe(ctx, "newHeads")
+			var rpcErr *types.RpcError
+			switch {
+			case errors.As(err, &rpcErr), errors.Is(err, errSubscriptionsNotSupported):
+				// The node does not support eth_subscribe; poll from now on.
+				poll = true
+				continue
+			case err != nil:
+				onError(err)
+			default:
+				err = consumeHeads(ctx, subscription, onHead)
+				subscription.Unsubscribe()
+				if err != nil {
+					onError(err)
+				}
+			}
+		} else {
+			blockNumber, err := c.GetBlockNumber(ctx)
+			if err != nil {
+				onError(err)
+			} else {
+				onHead(blockNumber)
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func consumeHeads(ctx context.Context, subscription types.Subscription, onHead func(number uint64)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-subscription.Err():
+			if !ok {
+				return nil
+			}
+			return err
+		case notification, ok := <-subscription.Notifications():
+			if !ok {
+				return errors.New("subscription closed")
+			}
+
+			var header struct {
+				Number string `json:"number"`
+			}
+			if err := json.Unmarshal(notification, &header); err != nil {
+				return fmt.Errorf("failed to unmarshal header: %v", err)
+			}
+			onHead(HexToUint64(header.Number))
+		}
+	}
+}
+
+// watchNewBlockNumbers reports block numbers above the last one reported,
+// optionally including the ones skipped in between.
+func (c *RpcClient) watchNewBlockNumbers(ctx context.Context, emitMissed, emitOnBegin, poll bool, interval time.Duration, emit func(number uint64, prev uint64), onError func(err error)) {
+	var prev uint64
+	seen := false
+
+	blockNumber, err := c.GetBlockNumber(ctx)
+	if err != nil {
+		onError(err)
+	} else {
+		prev, seen = blockNumber, true
+		if emitOnBegin {
+			emit(blockNumber, 0)
+		}
+	}
+
+	c.watchHeads(ctx, poll, interval, func(number uint64) {
+		if seen && number <= prev {
+			return
+		}
+		if seen && emitMissed {
+			for missed := prev + 1; missed < number && ctx.Err() == nil; missed++ {
+				emit(missed, prev)
+				prev = missed
+			}
+		}
+		if ctx.Err() == nil {
+			emit(number, prev)
+		}
+		prev, seen = number, true
+	}, onError)
+}
+
+func (c *RpcClient) WatchBlockNumber(params types.WatchBlockNumberParams) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	onError := func(err error) {
+		if params.OnError != nil && ctx.Err() == nil {
+			params.OnError(err)
+		}
+	}
+
+	go c.watchNewBlockNumbers(ctx, params.EmitMissed, params.EmitOnBegin, params.Poll, params.PollingInterval, func(number uint64, prev uint64) {
+		if params.OnBlockNumber != nil {
+			params.OnBlockNumber(number, prev)
+		}
+	}, onError)
+
+	return cancel
+}
+
+func (c *RpcClient) WatchBlocks(params types.WatchBlocksParams) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	onError := func(err error) {
+		if params.OnError != nil && ctx.Err() == nil {
+			params.OnError(err)
+		}
+	}
+
+	var prevBlock *types.Block
+	go c.watchNewBlockNumbers(ctx, params.EmitMissed, params.EmitOnBegin, params.Poll, params.PollingInterval, func(number uint64, prev uint64) {
+		block, err := c.GetBlock(ctx, types.GetBlockParams{BlockNumber: new(big.Int).SetUint64(number)})
+		if err != nil {
+			onError(err)
+			return
+		}
+		if params.OnBlock != nil && ctx.Err() == nil {
+			params.OnBlock(block, prevBlock)
+		}
+		prevBlock = block
+	}, onError)
+
+	return cancel
+}
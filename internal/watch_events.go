@@ -0,0 +1,339 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+const (
+	// trackedBlocks is how many recent blocks the poller remembers to detect
+	// reorgs; deeper reorgs are not rolled back.
+	trackedBlocks = 128
+	seenLogsLimit = 4096
+)
+
+type blockRef struct {
+	number uint64
+	hash   string
+}
+
+func (c *RpcClient) getBlockRef(ctx context.Context, blockNumber *big.Int) (*blockRef, error) {
+	tag := "latest"
+	if blockNumber != nil {
+		tag = fmt.Sprintf("0x%x", blockNumber)
+	}
+
+	result, err := c.Call(ctx, "eth_getBlockByNumber", []interface{}{tag, false})
+	if err != nil {
+		return nil, err
+	}
+
+	var header *struct {
+		Number string `json:"number"`
+		Hash   string `json:"hash"`
+	}
+	if err := json.Unmarshal(result, &header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal header: %v", err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %s not found", tag)
+	}
+
+	return &blockRef{number: HexToUint64(header.Number), hash: header.Hash}, nil
+}
+
+type eventWatcher struct {
+	client  *RpcClient
+	event   abi.Event
+	filter  types.GetLogsParams
+	onError func(err error)
+	emit    func(events []types.ContractEvent)
+
+	// polling state: recent canonical blocks and the events emitted for them
+	tracked []blockRef
+	emitted map[string][]types.ContractEvent
+	next    uint64
+
+	// subscription state: logs already delivered, to drop backfill duplicates
+	seen      map[string]struct{}
+	seenOrder []string
+	lastBlock uint64
+}
+
+func (c *RpcClient) WatchContractEvent(params types.WatchContractEventParams) (func(), error) {
+	parsedABI, err := abi.JSON(strings.NewReader(params.Abi))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	event, ok := parsedABI.Events[params.EventName]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", params.EventName)
+	}
+
+	topics, err := eventTopics(event, params.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &eventWatcher{
+		client: c,
+		event:  event,
+		filter: types.GetLogsParams{Address: params.Address, Topics: topics},
+		onError: func(err error) {
+			if params.OnError != nil && ctx.Err() == nil {
+				params.OnError(err)
+			}
+		},
+		emit: func(events []types.ContractEvent) {
+			if params.OnEvents != nil && len(events) > 0 && ctx.Err() == nil {
+				params.OnEvents(events)
+			}
+		},
+		emitted: make(map[string][]types.ContractEvent),
+		seen:    make(map[string]struct{}),
+	}
+
+	interval := params.PollingInterval
+	if interval <= 0 {
+		interval = defaultPollingInterval
+	}
+	go w.run(ctx, params.Poll, interval)
+
+	return cancel, nil
+}
+
+func (w *eventWatcher) run(ctx context.Context, poll bool, interval time.Duration) {
+	if _, ok := w.client.transport.(types.SubscriptionTransport); !ok {
+		poll = true
+	}
+
+	for ctx.Err() == nil {
+		if !poll {
+			subscription, err := w.client.subscribe(ctx, "logs", w.filter)
+			var rpcErr *types.RpcError
+			switch {
+			case errors.As(err, &rpcErr), errors.Is(err, errSubscriptionsNotSupported):
+				poll = true
+				continue
+			case err != nil:
+				w.onError(err)
+			default:
+				if err := w.consume(ctx, subscription); err != nil {
+					w.onError(err)
+				}
+				subscription.Unsubscribe()
+			}
+		} else if err := w.poll(ctx); err != nil {
+			w.onError(err)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (w *eventWatcher) decode(logs []types.Log) ([]types.ContractEvent, error) {
+	events := make([]types.ContractEvent, 0, len(logs))
+	for _, log := range logs {
+		decoded, err := decodeEventLog(w.event, log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *decoded)
+	}
+
+	return events, nil
+}
+
+// consume streams logs from a logs subscription. After a resubscription, the
+// blocks missed since the last delivered log are backfilled with eth_getLogs,
+// starting at that log's block in case the rest of it was cut off; logs that
+// were already delivered are dropped by deliver.
+func (w *eventWatcher) consume(ctx context.Context, subscription types.Subscription) error {
+	if w.lastBlock > 0 {
+		filter := w.filter
+		filter.FromBlock = new(big.Int).SetUint64(w.lastBlock)
+		latest := "latest"
+		filter.ToBlockTag = &latest
+
+		logs, err := w.client.GetLogs(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if err := w.deliver(logs); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-subscription.Err():
+			if !ok {
+				return nil
+			}
+			return err
+		case notification, ok := <-subscription.Notifications():
+			if !ok {
+				return errors.New("subscription closed")
+			}
+
+			var rawLog types.RawLog
+			if err := json.Unmarshal(notification, &rawLog); err != nil {
+				return fmt.Errorf("failed to unmarshal rawLog: %v", err)
+			}
+			if err := w.deliver([]types.Log{ConvertRawLog(rawLog)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *eventWatcher) deliver(logs []types.Log) error {
+	var fresh []types.Log
+	for _, log := range logs {
+		key := fmt.Sprintf("%s:%d:%t", log.BlockHash, log.LogIndex, log.Removed)
+		if _, ok := w.seen[key]; ok {
+			continue
+		}
+		w.seen[key] = struct{}{}
+		w.seenOrder = append(w.seenOrder, key)
+		if len(w.seenOrder) > seenLogsLimit {
+			delete(w.seen, w.seenOrder[0])
+			w.seenOrder = w.seenOrder[1:]
+		}
+		if log.BlockNumber != nil && !log.Removed && log.BlockNumber.Uint64() > w.lastBlock {
+			w.lastBlock = log.BlockNumber.Uint64()
+		}
+		fresh = append(fresh, log)
+	}
+
+	events, err := w.decode(fresh)
+	if err != nil {
+		return err
+	}
+	w.emit(events)
+
+	return nil
+}
+
+// poll fetches logs for the blocks mined since the previous poll. Before that
+// it checks the tracked blocks against the canonical chain and emits removals
+// for events in blocks that were reorged out.
+func (w *eventWatcher) poll(ctx context.Context) error {
+	head, err := w.client.getBlockRef(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if len(w.tracked) == 0 && w.next == 0 {
+		w.track(*head)
+		w.next = head.number + 1
+		return nil
+	}
+
+	if err := w.rollback(ctx); err != nil {
+		return err
+	}
+	if head.number < w.next {
+		return nil
+	}
+
+	filter := w.filter
+	filter.FromBlock = new(big.Int).SetUint64(w.next)
+	filter.ToBlock = new(big.Int).SetUint64(head.number)
+	logs, err := w.client.GetLogs(ctx, filter)
+	if err != nil {
+		return err
+	}
+	events, err := w.decode(logs)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if event.Log.BlockNumber == nil {
+			continue
+		}
+		if _, ok := w.emitted[event.Log.BlockHash]; !ok {
+			w.track(blockRef{number: event.Log.BlockNumber.Uint64(), hash: event.Log.BlockHash})
+		}
+		w.emitted[event.Log.BlockHash] = append(w.emitted[event.Log.BlockHash], event)
+	}
+	if len(w.tracked) == 0 || w.tracked[len(w.tracked)-1].number < head.number {
+		w.track(*head)
+	}
+	w.next = head.number + 1
+	w.emit(events)
+
+	return nil
+}
+
+// rollback walks the tracked blocks from the newest down until one is still
+// canonical, emitting removals for the events of every block above it. The
+// next poll refetches everything above the surviving block, since untracked
+// blocks in between may have been replaced too; if none survives, it starts
+// over from the oldest block that was tracked.
+func (w *eventWatcher) rollback(ctx context.Context) error {
+	if len(w.tracked) == 0 {
+		return nil
+	}
+	floor := w.tracked[0].number
+
+	reorged := false
+	for len(w.tracked) > 0 {
+		newest := w.tracked[len(w.tracked)-1]
+		canonical, err := w.client.getBlockRef(ctx, new(big.Int).SetUint64(newest.number))
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(canonical.hash, newest.hash) {
+			if reorged {
+				w.next = newest.number + 1
+			}
+			return nil
+		}
+		reorged = true
+
+		var removed []types.ContractEvent
+		for i := len(w.tracked) - 1; i >= 0 && w.tracked[i].number >= newest.number; i-- {
+			ref := w.tracked[i]
+			for _, event := range w.emitted[ref.hash] {
+				event.Log.Removed = true
+				removed = append(removed, event)
+			}
+			delete(w.emitted, ref.hash)
+			w.tracked = w.tracked[:i]
+		}
+		sort.SliceStable(removed, func(a, b int) bool {
+			return removed[a].Log.LogIndex > removed[b].Log.LogIndex
+		})
+		w.emit(removed)
+	}
+	w.next = floor
+
+	return nil
+}
+
+func (w *eventWatcher) track(ref blockRef) {
+	w.tracked = append(w.tracked, ref)
+	if len(w.tracked) > trackedBlocks {
+		delete(w.emitted, w.tracked[0].hash)
+		w.tracked = w.tracked[1:]
+	}
+}
@@ -0,0 +1,230 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+// closedSubscription ends as soon as it is consumed.
+type closedSubscription struct {
+	errCh chan error
+}
+
+func newClosedSubscription() *closedSubscription {
+	s := &closedSubscription{errCh: make(chan error)}
+	close(s.errCh)
+	return s
+}
+
+func (s *closedSubscription) Notifications() <-chan json.RawMessage { return nil }
+func (s *closedSubscription) Err() <-chan error                     { return s.errCh }
+func (s *closedSubscription) Unsubscribe() error                    { return nil }
+
+func transferLog(t *testing.T, block uint64, index uint64) types.RawLog {
+	t.Helper()
+	event := transferEvent(t)
+	return types.RawLog{
+		Topics: []string{
+			event.ID.Hex(),
+			common.BytesToHash([]byte{0xaa}).Hex(),
+			common.BytesToHash([]byte{0xbb}).Hex(),
+		},
+		Data:            common.BigToHash(big.NewInt(1)).Hex(),
+		BlockNumber:     fmt.Sprintf("0x%x", block),
+		BlockHash:       common.BigToHash(new(big.Int).SetUint64(block)).Hex(),
+		TransactionHash: common.BigToHash(new(big.Int).SetUint64(block*100 + index)).Hex(),
+		LogIndex:        fmt.Sprintf("0x%x", index),
+	}
+}
+
+func TestEventWatcherBackfillResumesPartialBlock(t *testing.T) {
+	var fromBlock string
+	server := newHttpStandIn(t, func(request types.RpcRequest) types.RpcResponse {
+		filter := request.Params.([]interface{})[0].(map[string]interface{})
+		fromBlock, _ = filter["fromBlock"].(string)
+		return rpcResult(request, []types.RawLog{transferLog(t, 5, 0), transferLog(t, 5, 1), transferLog(t, 6, 0)})
+	})
+
+	var delivered []string
+	w := &eventWatcher{
+		client: NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL}),
+		event:  transferEvent(t),
+		emit: func(events []types.ContractEvent) {
+			for _, event := range events {
+				delivered = append(delivered, fmt.Sprintf("%s:%d", event.Log.BlockNumber, event.Log.LogIndex))
+			}
+		},
+		seen: make(map[string]struct{}),
+	}
+	// The connection dropped after the first log of block 5.
+	if err := w.deliver([]types.Log{ConvertRawLog(transferLog(t, 5, 0))}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.consume(context.Background(), newClosedSubscription()); err != nil {
+		t.Fatal(err)
+	}
+	if fromBlock != "0x5" {
+		t.Errorf("backfill started at %s, want 0x5", fromBlock)
+	}
+	if fmt.Sprint(delivered) != "[5:0 5:1 6:0]" {
+		t.Errorf("got events %v, want [5:0 5:1 6:0]", delivered)
+	}
+}
+
+// reorgChain is a stand-in node whose blocks carry a fork label, "a12" for
+// block 12 of fork a, and a Transfer log in every block listed in events.
+type reorgChain struct {
+	mu     sync.Mutex
+	blocks []string
+	events map[string]bool
+}
+
+func newReorgChain(head uint64) *reorgChain {
+	chain := &reorgChain{events: make(map[string]bool)}
+	chain.extend("a", 0, head)
+	return chain
+}
+
+// extend replaces the chain from block from onwards with blocks of fork up to
+// head.
+func (c *reorgChain) extend(fork string, from uint64, head uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks = c.blocks[:from]
+	for number := from; number <= head; number++ {
+		c.blocks = append(c.blocks, fmt.Sprintf("%s%d", fork, number))
+	}
+}
+
+func (c *reorgChain) addEvent(label string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events[label] = true
+}
+
+func blockLabelHash(label string) string {
+	return common.BytesToHash([]byte(label)).Hex()
+}
+
+func (c *reorgChain) handler(t *testing.T) rpcHandler {
+	return func(request types.RpcRequest) types.RpcResponse {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		params := request.Params.([]interface{})
+		switch request.Method {
+		case "eth_getBlockByNumber":
+			number := uint64(len(c.blocks) - 1)
+			if tag := params[0].(string); tag != "latest" {
+				number = HexToUint64(tag)
+			}
+			return rpcResult(request, map[string]string{
+				"number": fmt.Sprintf("0x%x", number),
+				"hash":   blockLabelHash(c.blocks[number]),
+			})
+		case "eth_getLogs":
+			filter := params[0].(map[string]interface{})
+			var logs []types.RawLog
+			for number := HexToUint64(filter["fromBlock"].(string)); number <= HexToUint64(filter["toBlock"].(string)); number++ {
+				if label := c.blocks[number]; c.events[label] {
+					log := transferLog(t, number, 0)
+					log.BlockHash = blockLabelHash(label)
+					logs = append(logs, log)
+				}
+			}
+			return rpcResult(request, logs)
+		}
+		return rpcFailure(request, -32601, "unexpected call to "+request.Method)
+	}
+}
+
+func newPollingWatcher(t *testing.T, chain *reorgChain) (*eventWatcher, *[]string) {
+	t.Helper()
+	server := newHttpStandIn(t, chain.handler(t))
+	labels := make(map[string]string)
+	for _, fork := range []string{"a", "b"} {
+		for number := 0; number < 32; number++ {
+			label := fmt.Sprintf("%s%d", fork, number)
+			labels[blockLabelHash(label)] = label
+		}
+	}
+
+	var delivered []string
+	w := &eventWatcher{
+		client: NewRpcClient(types.NewRpcClientParams{RpcUrl: server.URL}),
+		event:  transferEvent(t),
+		emit: func(events []types.ContractEvent) {
+			for _, event := range events {
+				label := labels[strings.ToLower(event.Log.BlockHash)]
+				if event.Log.Removed {
+					label += " removed"
+				}
+				delivered = append(delivered, label)
+			}
+		},
+		emitted: make(map[string][]types.ContractEvent),
+	}
+	return w, &delivered
+}
+
+func TestEventWatcherPollingReorg(t *testing.T) {
+	chain := newReorgChain(10)
+	w, delivered := newPollingWatcher(t, chain)
+	poll := func() {
+		t.Helper()
+		if err := w.poll(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	poll()
+	chain.extend("a", 11, 13)
+	chain.addEvent("a12")
+	poll()
+
+	// Blocks 11 to 13 are replaced. Block 11 was never tracked, but the
+	// event of its replacement must still be delivered.
+	chain.extend("b", 11, 14)
+	chain.addEvent("b11")
+	chain.addEvent("b12")
+	poll()
+
+	if fmt.Sprint(*delivered) != "[a12 a12 removed b11 b12]" {
+		t.Errorf("got events %v, want [a12 a12 removed b11 b12]", *delivered)
+	}
+}
+
+func TestEventWatcherPollingReorgBelowTrackedBlocks(t *testing.T) {
+	chain := newReorgChain(10)
+	w, delivered := newPollingWatcher(t, chain)
+	poll := func() {
+		t.Helper()
+		if err := w.poll(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	poll()
+	chain.extend("a", 11, 12)
+	chain.addEvent("a11")
+	poll()
+
+	// Every tracked block is replaced, so the watcher refetches from the
+	// oldest one it tracked.
+	chain.extend("b", 10, 13)
+	chain.addEvent("b10")
+	chain.addEvent("b11")
+	poll()
+
+	if fmt.Sprint(*delivered) != "[a11 a11 removed b10 b11]" {
+		t.Errorf("got events %v, want [a11 a11 removed b10 b11]", *delivered)
+	}
+}
@@ -0,0 +1,19 @@
+package mjolnir
+
+import (
+	"github.com/sunsetlover36/mjolnir/internal"
+	"github.com/sunsetlover36/mjolnir/types"
+)
+
+func NewHttpTransport(params types.NewHttpTransportParams) types.Transport {
+	return internal.NewHttpTransport(params)
+}
+func NewWebSocketTransport(params types.NewWebSocketTransportParams) types.Transport {
+	return internal.NewWebSocketTransport(params)
+}
+func NewIpcTransport(params types.NewIpcTransportParams) types.Transport {
+	return internal.NewIpcTransport(params)
+}
+func NewFallbackTransport(params types.NewFallbackTransportParams) types.Transport {
+	return internal.NewFallbackTransport(params)
+}
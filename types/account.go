@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignHash signs a 32-byte digest and returns the 65-byte r || s || v
+// signature, hex encoded, with v set to 27 or 28.
+func (a *Account) SignHash(hash []byte) (string, error) {
+	if a.PrivateKey == nil {
+		return "", fmt.Errorf("account %s has no private key", a.Address)
+	}
+
+	signature, err := crypto.Sign(hash, a.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign hash: %v", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return hexutil.Encode(signature), nil
+}
+
+// SignMessage signs message with the EIP-191 personal_sign prefix. Text
+// messages are passed as their UTF-8 bytes.
+func (a *Account) SignMessage(message []byte) (string, error) {
+	return a.SignHash(accounts.TextHash(message))
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// AuthorizationExecutorSelf marks an authorization that is sent by its own
+// authority, whose transaction consumes a nonce before the authorization is
+// applied.
+const AuthorizationExecutorSelf = "self"
+
+// Authorization delegates the code of the signing account to Address
+// (EIP-7702). A zero ChainId makes it valid on every chain.
+type Authorization struct {
+	ChainId *big.Int
+	Address string
+	Nonce   uint64
+}
+
+type RawSignedAuthorization struct {
+	ChainId string `json:"chainId"`
+	Address string `json:"address"`
+	Nonce   string `json:"nonce"`
+	YParity string `json:"yParity"`
+	V       string `json:"v"`
+	R       string `json:"r"`
+	S       string `json:"s"`
+}
+type SignedAuthorization struct {
+	ChainId *big.Int
+	Address string
+	Nonce   uint64
+	YParity uint8
+	R       *big.Int
+	S       *big.Int
+}
+
+func (a SignedAuthorization) MarshalJSON() ([]byte, error) {
+	chainId := a.ChainId
+	if chainId == nil {
+		chainId = new(big.Int)
+	}
+	return json.Marshal(map[string]string{
+		"chainId": fmt.Sprintf("0x%x", chainId),
+		"address": a.Address,
+		"nonce":   fmt.Sprintf("0x%x", a.Nonce),
+		"yParity": fmt.Sprintf("0x%x", a.YParity),
+		"r":       fmt.Sprintf("0x%x", a.R),
+		"s":       fmt.Sprintf("0x%x", a.S),
+	})
+}
+
+type SignAuthorizationParams struct {
+	ContractAddress string
+	// ChainId defaults to the client's chain.
+	ChainId *big.Int
+	// Nonce defaults to the account's pending nonce, plus one when Executor
+	// is AuthorizationExecutorSelf. That guess is wrong while other
+	// transactions of the account are in flight; use
+	// TxData.SelfAuthorizations to derive it from the transaction instead.
+	Nonce *uint64
+	// Executor is AuthorizationExecutorSelf when the signing account also
+	// sends the transaction, or the sponsor's address otherwise.
+	Executor string
+}
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+type BatchOptions struct {
+	// Wait is how long calls are collected before the batch is sent.
+	Wait time.Duration
+	// MaxSize sends the batch early once this many calls are queued.
+	MaxSize int
+}
+
+type BatchResult[T any] struct {
+	Value T
+	Err   error
+}
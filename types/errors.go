@@ -0,0 +1,111 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNonceTooLow            = errors.New("nonce too low")
+	ErrNonceTooHigh           = errors.New("nonce too high")
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+	ErrExecutionReverted      = errors.New("execution reverted")
+	ErrIntrinsicGasTooLow     = errors.New("intrinsic gas too low")
+
+	ErrTransactionNotFound        = errors.New("transaction not found")
+	ErrTransactionReceiptNotFound = errors.New("transaction receipt not found")
+	ErrTransactionDropped         = errors.New("transaction dropped")
+
+	ErrUnpackResult = errors.New("failed to unpack result")
+
+	ErrNoSigner = errors.New("no account or signer")
+)
+
+type HttpStatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration
+	Body       []byte
+}
+
+func (e *HttpStatusError) Error() string {
+	return fmt.Sprintf("unexpected http status: %s", e.Status)
+}
+
+func (e *RpcError) Error() string {
+	return fmt.Sprintf("rpc error: %s", e.Message)
+}
+
+// Is matches well-known node errors against the sentinel errors above, so
+// callers can use errors.Is(err, types.ErrNonceTooLow).
+func (e *RpcError) Is(target error) bool {
+	return e.Kind() == target
+}
+
+// Kind returns the sentinel error the node's message maps to, or nil.
+func (e *RpcError) Kind() error {
+	message := strings.ToLower(e.Message)
+	switch {
+	case strings.Contains(message, "nonce too low"):
+		return ErrNonceTooLow
+	case strings.Contains(message, "nonce too high"):
+		return ErrNonceTooHigh
+	case strings.Contains(message, "insufficient funds"):
+		return ErrInsufficientFunds
+	case strings.Contains(message, "replacement") && strings.Contains(message, "underpriced"):
+		return ErrReplacementUnderpriced
+	case strings.Contains(message, "intrinsic gas too low"):
+		return ErrIntrinsicGasTooLow
+	case e.Code == 3 || strings.Contains(message, "execution reverted"):
+		return ErrExecutionReverted
+	}
+
+	return nil
+}
+
+type TimeoutError struct {
+	Method string
+	Err    error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("request %s timed out: %v", e.Method, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+type ContractRevertError struct {
+	// Name is "Error", "Panic" or the name of the custom error.
+	Name      string
+	Signature string
+	Args      []interface{}
+	// Reason is the Error(string) message or the description of a panic code.
+	Reason    string
+	PanicCode *big.Int
+	Data      []byte
+	Err       error
+}
+
+func (e *ContractRevertError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	}
+	if e.Name != "" {
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+	}
+	return fmt.Sprintf("execution reverted: 0x%x", e.Data)
+}
+
+func (e *ContractRevertError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"context"
+	"math/big"
+)
+
+type FeeSpeed string
+
+const (
+	FeeSpeedSlow   FeeSpeed = "slow"
+	FeeSpeedNormal FeeSpeed = "normal"
+	FeeSpeedFast   FeeSpeed = "fast"
+)
+
+type EstimateFeesPerGasParams struct {
+	// BlockCount is the number of recent blocks sampled. Defaults to 10.
+	BlockCount uint64
+	// Percentiles of the priority fees paid in each block behind the slow,
+	// normal and fast suggestions. Defaults to 10, 50 and 90.
+	Percentiles *[3]float64
+	// BaseFeeMultiplier pads the next block's base fee in MaxFeePerGas so the
+	// transaction stays includable while base fees rise. Defaults to 2.
+	BaseFeeMultiplier float64
+	// Speed picks the suggestion used as MaxPriorityFeePerGas. Defaults to
+	// FeeSpeedNormal.
+	Speed FeeSpeed
+}
+
+type FeeEstimate struct {
+	// BaseFeePerGas is the base fee of the next block.
+	BaseFeePerGas           *big.Int
+	SlowPriorityFeePerGas   *big.Int
+	NormalPriorityFeePerGas *big.Int
+	FastPriorityFeePerGas   *big.Int
+	MaxPriorityFeePerGas    *big.Int
+	MaxFeePerGas            *big.Int
+}
+
+// FeeStrategy picks the fees of transactions that leave them unset.
+type FeeStrategy interface {
+	// FeesPerGas returns an estimate whose MaxFeePerGas and
+	// MaxPriorityFeePerGas are used. estimate reads the node's fee history.
+	FeesPerGas(ctx context.Context, estimate func(ctx context.Context, params EstimateFeesPerGasParams) (*FeeEstimate, error)) (*FeeEstimate, error)
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+type GetLogsParams struct {
+	Address []string
+	// Topics are matched by position; each position is a list of
+	// alternatives and a nil position matches anything.
+	Topics       [][]string
+	FromBlock    *big.Int
+	FromBlockTag *string
+	ToBlock      *big.Int
+	ToBlockTag   *string
+	BlockHash    *string
+}
+
+func (p GetLogsParams) MarshalJSON() ([]byte, error) {
+	filter := map[string]interface{}{}
+	if len(p.Address) > 0 {
+		filter["address"] = p.Address
+	}
+	if len(p.Topics) > 0 {
+		topics := make([]interface{}, len(p.Topics))
+		for i, alternatives := range p.Topics {
+			if len(alternatives) > 0 {
+				topics[i] = alternatives
+			}
+		}
+		filter["topics"] = topics
+	}
+	if p.BlockHash != nil {
+		filter["blockHash"] = *p.BlockHash
+		return json.Marshal(filter)
+	}
+	if p.FromBlock != nil {
+		filter["fromBlock"] = fmt.Sprintf("0x%x", p.FromBlock)
+	} else if p.FromBlockTag != nil {
+		filter["fromBlock"] = *p.FromBlockTag
+	}
+	if p.ToBlock != nil {
+		filter["toBlock"] = fmt.Sprintf("0x%x", p.ToBlock)
+	} else if p.ToBlockTag != nil {
+		filter["toBlock"] = *p.ToBlockTag
+	}
+
+	return json.Marshal(filter)
+}
+
+type GetContractEventsParams struct {
+	Address   []string
+	Abi       string
+	EventName string
+	// Args filters on indexed event arguments by name. A []interface{} value
+	// matches any of its elements.
+	Args         map[string]interface{}
+	FromBlock    *big.Int
+	FromBlockTag *string
+	ToBlock      *big.Int
+	ToBlockTag   *string
+	BlockHash    *string
+}
+
+type ContractEvent struct {
+	EventName string
+	Args      map[string]interface{}
+	Log       Log
+}
@@ -0,0 +1,27 @@
+package types
+
+import "context"
+
+// NonceManager hands out nonces per chain and address so that concurrent
+// sends never reuse one. It can be shared between clients.
+type NonceManager interface {
+	// Next reserves a nonce. fetch returns the node's pending nonce, which
+	// lets the manager skip nonces consumed outside of it.
+	Next(ctx context.Context, chainId int64, address string, fetch func(ctx context.Context) (uint64, error)) (uint64, error)
+	// NextRange reserves count consecutive nonces and returns the first, for
+	// transactions that also consume nonces through authorizations signed by
+	// their sender.
+	NextRange(ctx context.Context, chainId int64, address string, count uint64, fetch func(ctx context.Context) (uint64, error)) (uint64, error)
+	// Release gives back a reserved nonce whose transaction was not sent.
+	Release(chainId int64, address string, nonce uint64)
+	// Confirm marks a reserved nonce as used by a transaction the node
+	// accepted.
+	Confirm(chainId int64, address string, nonce uint64)
+	// Resync re-reads the node's pending nonce after the node rejected a
+	// nonce as too high. Nonces from there on that are not reserved are
+	// handed out again; nonces still reserved by concurrent sends are kept.
+	Resync(ctx context.Context, chainId int64, address string, fetch func(ctx context.Context) (uint64, error)) error
+	// Reset forgets the local state so the next call starts from the node's
+	// pending nonce.
+	Reset(chainId int64, address string)
+}
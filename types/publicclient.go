@@ -1,5 +1,10 @@
 package types
 
 type NewPublicClientParams struct {
-	RpcUrl string
+	RpcUrl    string
+	Transport Transport
+	Batch     *BatchOptions
+	Retry     *RetryPolicy
+	// FeeStrategy defaults to EstimateFeesPerGas with default parameters.
+	FeeStrategy FeeStrategy
 }
@@ -0,0 +1,13 @@
+package types
+
+type ReplaceTransactionParams struct {
+	Hash string
+	// FeeBumpPercent raises every fee of the original transaction by at least
+	// this much. Defaults to 10, the minimum most nodes accept for a
+	// replacement.
+	FeeBumpPercent uint64
+	Account        *Account
+	// Signer signs the replacement. It defaults to a local signer for
+	// Account.
+	Signer Signer
+}
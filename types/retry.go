@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt. Defaults to 3.
+	MaxAttempts int
+	// InitialBackoff defaults to 150ms and grows by Multiplier (default 2)
+	// after every attempt, up to MaxBackoff (default 5s).
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomly shortens each backoff by up to this fraction (0-1).
+	Jitter float64
+	// RetryableCodes replaces the default set of retryable JSON-RPC error codes.
+	RetryableCodes []int
+}
@@ -0,0 +1,13 @@
+package types
+
+type VerifySignatureParams struct {
+	Address string
+	// Exactly one of Hash, Message and TypedData is what was signed: a raw
+	// 32-byte digest, an EIP-191 message or an EIP-712 payload.
+	Hash      string
+	Message   []byte
+	TypedData *TypedData
+	// Signature may be an EOA signature, an ERC-1271 contract signature or an
+	// ERC-6492 wrapped signature of a wallet that is not deployed yet.
+	Signature string
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"context"
+	"math/big"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer signs on behalf of an address. Implementations can keep the key
+// outside of the process, e.g. in a KMS, an HSM or a remote signer.
+type Signer interface {
+	Address() string
+	// SignTransaction returns tx signed for chainId.
+	SignTransaction(ctx context.Context, tx *ethTypes.Transaction, chainId *big.Int) (*ethTypes.Transaction, error)
+	// SignHash signs a 32-byte digest and returns the 65-byte r || s || v
+	// signature, hex encoded, with v set to 27 or 28. It is needed for
+	// set-code transactions and authorizations; signers that cannot sign raw
+	// digests return an error.
+	SignHash(ctx context.Context, hash []byte) (string, error)
+	// SignMessage signs message with the EIP-191 personal_sign prefix.
+	SignMessage(ctx context.Context, message []byte) (string, error)
+	// SignTypedData signs the EIP-712 digest of typedData.
+	SignTypedData(ctx context.Context, typedData TypedData) (string, error)
+}
+
+type NewRemoteSignerParams struct {
+	RpcUrl    string
+	Transport Transport
+	Retry     *RetryPolicy
+	// Address selects one of the service's accounts. It defaults to the
+	// first account returned by eth_accounts.
+	Address string
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type Transport interface {
+	Request(ctx context.Context, request RpcRequest) (*RpcResponse, error)
+	BatchRequest(ctx context.Context, requests []RpcRequest) ([]RpcResponse, error)
+	Close() error
+}
+
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+type NewHttpTransportParams struct {
+	Url         string
+	HttpClient  *http.Client
+	Headers     http.Header
+	BasicAuth   *BasicAuth
+	BearerToken string
+}
+
+type NewWebSocketTransportParams struct {
+	Url         string
+	Headers     http.Header
+	DialTimeout time.Duration
+}
+
+type NewIpcTransportParams struct {
+	Path        string
+	DialTimeout time.Duration
+}
+
+type NewFallbackTransportParams struct {
+	// Transports are tried in order until one of them serves the request.
+	Transports []Transport
+	// Rank, when set, periodically probes every transport and reorders them
+	// by measured latency and stability.
+	Rank *FallbackRankOptions
+	// OnResponse is called after every attempt, including failed ones.
+	OnResponse func(response FallbackResponse)
+}
+
+type FallbackRankOptions struct {
+	Interval        time.Duration
+	Timeout         time.Duration
+	SampleCount     int
+	LatencyWeight   float64
+	StabilityWeight float64
+}
+
+type FallbackResponse struct {
+	Index     int
+	Transport Transport
+	Methods   []string
+	Duration  time.Duration
+	Err       error
+}
+
+// SubscriptionTransport is implemented by transports that support
+// eth_subscribe, such as WebSocket and IPC.
+type SubscriptionTransport interface {
+	Transport
+	Subscribe(ctx context.Context, params []interface{}) (Subscription, error)
+}
+
+type Subscription interface {
+	// Notifications delivers the result of every eth_subscription message.
+	Notifications() <-chan json.RawMessage
+	// Err receives an error when the subscription breaks and is then closed.
+	Err() <-chan error
+	Unsubscribe() error
+}
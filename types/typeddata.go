@@ -0,0 +1,76 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedDataDomain holds the EIP-712 domain. Unless Types declares
+// EIP712Domain, only the fields that are set are part of the domain.
+type TypedDataDomain struct {
+	Name              string   `json:"name,omitempty"`
+	Version           string   `json:"version,omitempty"`
+	ChainId           *big.Int `json:"chainId,omitempty"`
+	VerifyingContract string   `json:"verifyingContract,omitempty"`
+	Salt              string   `json:"salt,omitempty"`
+}
+
+func (d *TypedDataDomain) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name              string          `json:"name"`
+		Version           string          `json:"version"`
+		ChainId           json.RawMessage `json:"chainId"`
+		VerifyingContract string          `json:"verifyingContract"`
+		Salt              string          `json:"salt"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*d = TypedDataDomain{
+		Name:              raw.Name,
+		Version:           raw.Version,
+		VerifyingContract: raw.VerifyingContract,
+		Salt:              raw.Salt,
+	}
+	// chainId is a number or a decimal or hex string.
+	if chainIdStr := strings.Trim(string(raw.ChainId), `"`); chainIdStr != "" && chainIdStr != "null" {
+		chainId, ok := new(big.Int).SetString(chainIdStr, 0)
+		if !ok {
+			return fmt.Errorf("invalid domain chainId: %s", chainIdStr)
+		}
+		d.ChainId = chainId
+	}
+	return nil
+}
+
+// TypedData is an EIP-712 payload. Message values may be Go values (big
+// integers, addresses, byte slices, nested maps and slices) or the strings
+// and numbers of its JSON form.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      TypedDataDomain             `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+func (t *TypedData) UnmarshalJSON(data []byte) error {
+	type Alias TypedData
+	var alias Alias
+	// Keep large integers in the message exact.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&alias); err != nil {
+		return err
+	}
+	*t = TypedData(alias)
+	return nil
+}
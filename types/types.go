@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
@@ -16,8 +17,15 @@ type Chain struct {
 }
 
 type NewRpcClientParams struct {
-	RpcUrl string
-	Chain  Chain
+	RpcUrl    string
+	Transport Transport
+	Batch     *BatchOptions
+	Retry     *RetryPolicy
+	Chain     Chain
+	// NonceManager, when set, hands out the nonces of sent transactions.
+	NonceManager NonceManager
+	// FeeStrategy defaults to EstimateFeesPerGas with default parameters.
+	FeeStrategy FeeStrategy
 }
 
 type RpcRequest struct {
@@ -35,8 +43,9 @@ type RpcResponse struct {
 }
 
 type RpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
 }
 
 type GetBlockTransactionCountParams struct {
@@ -52,12 +61,14 @@ type GetBlockParams struct {
 
 // eth_call params
 type CallParams struct {
-	From     string   `json:"from"`
-	To       string   `json:"to"`
-	Gas      uint64   `json:"gas,omitempty"`
-	GasPrice *big.Int `json:"gasPrice,omitempty"`
-	Value    *big.Int `json:"value"`
-	Data     []byte   `json:"data"`
+	From              string                `json:"from"`
+	To                string                `json:"to"`
+	Gas               uint64                `json:"gas,omitempty"`
+	GasPrice          *big.Int              `json:"gasPrice,omitempty"`
+	Value             *big.Int              `json:"value"`
+	Data              []byte                `json:"data"`
+	AccessList        AccessList            `json:"accessList,omitempty"`
+	AuthorizationList []SignedAuthorization `json:"authorizationList,omitempty"`
 }
 
 func (c CallParams) MarshalJSON() ([]byte, error) {
@@ -107,60 +118,251 @@ type ContractInteractionParams struct {
 	Args                 []interface{}
 	MaxFeePerGas         *big.Int
 	MaxPriorityFeePerGas *big.Int
-	GasLimit             uint64
-	Value                *big.Int
-	Nonce                uint64
-	Account              *Account
+	// GasLimit is estimated when nil.
+	GasLimit *uint64
+	Value    *big.Int
+	// Nonce is taken from the nonce manager or the node when nil.
+	Nonce   *uint64
+	Account *Account
+	// Signer signs the transaction. It defaults to a local signer for
+	// Account.
+	Signer Signer
+	// AutoAccessList attaches the access list from eth_createAccessList
+	// when it lowers the estimated gas.
+	AutoAccessList bool
+	// AuthorizationList makes this an EIP-7702 set-code transaction.
+	AuthorizationList []SignedAuthorization
+	// SelfAuthorizations are signed by the sender with the nonces following
+	// the transaction's own.
+	SelfAuthorizations []Authorization
 }
 
 type TxInteractionParams struct {
 	TxData  *TxData
 	Account *Account
+	// Signer signs the transaction. It defaults to a local signer for
+	// Account.
+	Signer Signer
+	// AutoAccessList attaches the access list from eth_createAccessList
+	// when it lowers the estimated gas.
+	AutoAccessList bool
 }
+type TxType string
+
+const (
+	TxTypeLegacy  TxType = "legacy"
+	TxTypeEip2930 TxType = "eip2930"
+	TxTypeEip1559 TxType = "eip1559"
+	TxTypeEip4844 TxType = "eip4844"
+	TxTypeEip7702 TxType = "eip7702"
+)
+
+type AccessListItem struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+type AccessList []AccessListItem
+
+type RawAccessListResult struct {
+	AccessList AccessList `json:"accessList"`
+	GasUsed    string     `json:"gasUsed"`
+	Error      string     `json:"error"`
+}
+type AccessListResult struct {
+	AccessList AccessList `json:"accessList"`
+	GasUsed    *big.Int   `json:"gasUsed"`
+}
+
 type TxData struct {
-	ChainId              *big.Int
-	Nonce                uint64
+	// Type is detected from the fee fields and the latest block when empty.
+	Type    TxType
+	ChainId *big.Int
+	// Nonce is taken from the nonce manager or the node when nil.
+	Nonce                *uint64
 	MaxFeePerGas         *big.Int
 	MaxPriorityFeePerGas *big.Int
-	Gas                  uint64
-	To                   string
-	Value                *big.Int
-	Data                 []byte
+	// GasPrice is used by legacy and EIP-2930 transactions.
+	GasPrice *big.Int
+	// Gas is estimated when nil.
+	Gas        *uint64
+	To         string
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+	// BlobData is packed into blobs and sent as an EIP-4844 sidecar.
+	BlobData []byte
+	// MaxFeePerBlobGas defaults to twice the current blob base fee.
+	MaxFeePerBlobGas *big.Int
+	// AuthorizationList makes this an EIP-7702 set-code transaction.
+	AuthorizationList []SignedAuthorization
+	// SelfAuthorizations are signed by the sender when the transaction is
+	// sent, with the nonces following the transaction's own, and appended
+	// to AuthorizationList. Their Nonce is ignored.
+	SelfAuthorizations []Authorization
 }
 type SendTxOptions struct {
 	Simulate bool
 }
 type SimulateTxResult struct {
+	// Tx is the transaction SendTx would send, signed when a signer is set.
+	// For set-code transactions it is an unsigned dynamic fee transaction
+	// with the same fields but no authorization list, not the transaction
+	// that is sent.
 	Tx     *ethTypes.Transaction
 	Result string
 }
 
-type FeeHistoryResult struct {
-	OldestBlock   string
-	Reward        [][]string
-	BaseFeePerGas []string
-	GasUsedRatio  []float64
+type RawFeeHistory struct {
+	OldestBlock       string     `json:"oldestBlock"`
+	Reward            [][]string `json:"reward"`
+	BaseFeePerGas     []string   `json:"baseFeePerGas"`
+	GasUsedRatio      []float64  `json:"gasUsedRatio"`
+	BaseFeePerBlobGas []string   `json:"baseFeePerBlobGas"`
+	BlobGasUsedRatio  []float64  `json:"blobGasUsedRatio"`
+}
+
+// FeeHistoryResult is the former name of RawFeeHistory.
+//
+// Deprecated: Use RawFeeHistory, or FeeHistory for decoded values.
+type FeeHistoryResult = RawFeeHistory
+
+type FeeHistory struct {
+	OldestBlock *big.Int `json:"oldestBlock"`
+	// Reward holds, per block, the priority fee at each requested percentile.
+	Reward [][]*big.Int `json:"reward"`
+	// BaseFeePerGas has one more entry than there are blocks: the base fee of
+	// the block after the newest one.
+	BaseFeePerGas []*big.Int `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+	// The blob fields are nil when the node does not report them.
+	BaseFeePerBlobGas []*big.Int `json:"baseFeePerBlobGas"`
+	BlobGasUsedRatio  []float64  `json:"blobGasUsedRatio"`
+}
+
+type GetFeeHistoryParams struct {
+	BlockCount uint64
+	// NewestBlock takes precedence over NewestBlockTag, which defaults to
+	// "latest".
+	NewestBlock       *big.Int
+	NewestBlockTag    *string
+	RewardPercentiles []float64
 }
 
 type RawTransaction struct {
-	Hash             string `json:"hash"`
-	From             string `json:"from"`
-	To               string `json:"to,omitempty"`
-	Value            string `json:"value"`
-	GasPrice         string `json:"gasPrice"`
-	Gas              string `json:"gas"`
-	Nonce            string `json:"nonce"`
-	TransactionIndex string `json:"transactionIndex"`
+	Hash                 string                   `json:"hash"`
+	BlockHash            string                   `json:"blockHash"`
+	BlockNumber          string                   `json:"blockNumber"`
+	From                 string                   `json:"from"`
+	To                   string                   `json:"to,omitempty"`
+	Value                string                   `json:"value"`
+	GasPrice             string                   `json:"gasPrice"`
+	MaxFeePerGas         string                   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string                   `json:"maxPriorityFeePerGas"`
+	Gas                  string                   `json:"gas"`
+	Nonce                string                   `json:"nonce"`
+	Input                string                   `json:"input"`
+	Type                 string                   `json:"type"`
+	ChainId              string                   `json:"chainId"`
+	TransactionIndex     string                   `json:"transactionIndex"`
+	AccessList           AccessList               `json:"accessList"`
+	AuthorizationList    []RawSignedAuthorization `json:"authorizationList"`
 }
 type Transaction struct {
-	Hash             string   `json:"hash"`
-	From             string   `json:"from"`
-	To               string   `json:"to,omitempty"`
-	Value            *big.Int `json:"value"`
-	GasPrice         *big.Int `json:"gasPrice"`
-	Gas              uint64   `json:"gas"`
-	Nonce            string   `json:"nonce"`
+	Hash                 string                `json:"hash"`
+	BlockHash            string                `json:"blockHash"`
+	BlockNumber          *big.Int              `json:"blockNumber"`
+	From                 string                `json:"from"`
+	To                   string                `json:"to,omitempty"`
+	Value                *big.Int              `json:"value"`
+	GasPrice             *big.Int              `json:"gasPrice"`
+	MaxFeePerGas         *big.Int              `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int              `json:"maxPriorityFeePerGas"`
+	Gas                  uint64                `json:"gas"`
+	Nonce                string                `json:"nonce"`
+	Input                string                `json:"input"`
+	Type                 uint64                `json:"type"`
+	ChainId              *big.Int              `json:"chainId"`
+	TransactionIndex     uint64                `json:"transactionIndex"`
+	AccessList           AccessList            `json:"accessList"`
+	AuthorizationList    []SignedAuthorization `json:"authorizationList"`
+}
+
+type RawLog struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      *big.Int `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
 	TransactionIndex uint64   `json:"transactionIndex"`
+	LogIndex         uint64   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+type RawReceipt struct {
+	TransactionHash   string   `json:"transactionHash"`
+	TransactionIndex  string   `json:"transactionIndex"`
+	BlockHash         string   `json:"blockHash"`
+	BlockNumber       string   `json:"blockNumber"`
+	From              string   `json:"from"`
+	To                string   `json:"to"`
+	CumulativeGasUsed string   `json:"cumulativeGasUsed"`
+	GasUsed           string   `json:"gasUsed"`
+	EffectiveGasPrice string   `json:"effectiveGasPrice"`
+	ContractAddress   string   `json:"contractAddress"`
+	Logs              []RawLog `json:"logs"`
+	LogsBloom         string   `json:"logsBloom"`
+	Status            string   `json:"status"`
+	Type              string   `json:"type"`
+}
+type Receipt struct {
+	TransactionHash   string   `json:"transactionHash"`
+	TransactionIndex  uint64   `json:"transactionIndex"`
+	BlockHash         string   `json:"blockHash"`
+	BlockNumber       *big.Int `json:"blockNumber"`
+	From              string   `json:"from"`
+	To                string   `json:"to"`
+	CumulativeGasUsed uint64   `json:"cumulativeGasUsed"`
+	GasUsed           uint64   `json:"gasUsed"`
+	EffectiveGasPrice *big.Int `json:"effectiveGasPrice"`
+	ContractAddress   string   `json:"contractAddress"`
+	Logs              []Log    `json:"logs"`
+	LogsBloom         string   `json:"logsBloom"`
+	// Status is 1 for success and 0 for a reverted transaction.
+	Status uint64 `json:"status"`
+	Type   uint64 `json:"type"`
+}
+
+type WaitForTransactionReceiptParams struct {
+	Hash string
+	// Confirmations is the number of blocks, including the one the
+	// transaction was mined in, to wait for. Defaults to 1.
+	Confirmations   uint64
+	PollingInterval time.Duration
+	Timeout         time.Duration
+	// OnReplaced is called when the transaction is replaced by another one
+	// with the same sender and nonce.
+	OnReplaced func(replacement TransactionReplacement)
+}
+
+type TransactionReplacement struct {
+	// Reason is "repriced" when only the fees changed, "cancelled" for a
+	// zero-value self-transfer, and "replaced" otherwise.
+	Reason              string
+	ReplacedTransaction *Transaction
+	Transaction         *Transaction
+	Receipt             *Receipt
 }
 
 type RawBlock struct {
@@ -180,6 +382,7 @@ type RawBlock struct {
 	GasLimit         string           `json:"gasLimit"`
 	GasUsed          string           `json:"gasUsed"`
 	Timestamp        string           `json:"timestamp"`
+	BaseFeePerGas    string           `json:"baseFeePerGas"`
 	Transactions     []RawTransaction `json:"transactions"`
 	Uncles           []string         `json:"uncles"`
 }
@@ -200,6 +403,7 @@ type Block struct {
 	GasLimit         *big.Int      `json:"gasLimit"`
 	GasUsed          *big.Int      `json:"gasUsed"`
 	Timestamp        *big.Int      `json:"timestamp"`
+	BaseFeePerGas    *big.Int      `json:"baseFeePerGas"`
 	Transactions     []Transaction `json:"transactions"`
 	Uncles           []string      `json:"uncles"`
 }
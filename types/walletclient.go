@@ -1,7 +1,18 @@
 package types
 
 type NewWalletClientParams struct {
-	RpcUrl  string
-	Chain   Chain
-	Account *Account
+	RpcUrl    string
+	Transport Transport
+	Batch     *BatchOptions
+	Retry     *RetryPolicy
+	Chain     Chain
+	Account   *Account
+	// Signer signs on behalf of the client. It defaults to a local signer
+	// for Account. Without either, the methods that sign or act for the
+	// account return ErrNoSigner.
+	Signer Signer
+	// NonceManager defaults to one owned by the client.
+	NonceManager NonceManager
+	// FeeStrategy defaults to EstimateFeesPerGas with default parameters.
+	FeeStrategy FeeStrategy
 }
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+type WatchBlockNumberParams struct {
+	OnBlockNumber func(blockNumber uint64, prevBlockNumber uint64)
+	OnError       func(err error)
+	// EmitMissed emits every block number skipped between two observations
+	// instead of only the latest one.
+	EmitMissed  bool
+	EmitOnBegin bool
+	// Poll forces polling even when the transport supports eth_subscribe.
+	Poll            bool
+	PollingInterval time.Duration
+}
+
+type WatchBlocksParams struct {
+	OnBlock         func(block *Block, prevBlock *Block)
+	OnError         func(err error)
+	EmitMissed      bool
+	EmitOnBegin     bool
+	Poll            bool
+	PollingInterval time.Duration
+}
+
+type WatchContractEventParams struct {
+	Address   []string
+	Abi       string
+	EventName string
+	Args      map[string]interface{}
+	// OnEvents receives events in chain order. Events whose log was reorged
+	// out are emitted again with Log.Removed set.
+	OnEvents        func(events []ContractEvent)
+	OnError         func(err error)
+	Poll            bool
+	PollingInterval time.Duration
+}
@@ -1,8 +1,12 @@
 package mjolnir
 
 import (
+	"context"
 	"math/big"
 
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
 	"github.com/sunsetlover36/mjolnir/internal"
 	"github.com/sunsetlover36/mjolnir/types"
 )
@@ -13,6 +17,36 @@ func GeneratePrivateKey() (string, error) {
 func PrivateKeyToAccount(privateKeyHex string) (*types.Account, error) {
 	return internal.PrivateKeyToAccount(privateKeyHex)
 }
+func NewLocalSigner(account *types.Account) types.Signer {
+	return internal.NewLocalSigner(account)
+}
+func NewRemoteSigner(params types.NewRemoteSignerParams) (types.Signer, error) {
+	return NewRemoteSignerContext(context.Background(), params)
+}
+func NewRemoteSignerContext(ctx context.Context, params types.NewRemoteSignerParams) (types.Signer, error) {
+	return internal.NewRemoteSigner(ctx, params)
+}
+func HashMessage(message []byte) string {
+	return internal.HashMessage(message)
+}
+func RecoverMessageAddress(message []byte, signature string) (string, error) {
+	return internal.RecoverMessageAddress(message, signature)
+}
+func VerifyMessage(address string, message []byte, signature string) (bool, error) {
+	return internal.VerifyMessage(address, message, signature)
+}
+func HashTypedData(typedData types.TypedData) (string, error) {
+	return internal.HashTypedData(typedData)
+}
+func SignTypedData(account *types.Account, typedData types.TypedData) (string, error) {
+	return internal.SignTypedData(account, typedData)
+}
+func RecoverTypedDataAddress(typedData types.TypedData, signature string) (string, error) {
+	return internal.RecoverTypedDataAddress(typedData, signature)
+}
+func VerifyTypedData(address string, typedData types.TypedData, signature string) (bool, error) {
+	return internal.VerifyTypedData(address, typedData, signature)
+}
 func ParseEther(etherStr string) (*big.Int, error) {
 	return internal.ParseEther(etherStr)
 }
@@ -25,3 +59,27 @@ func ParseGwei(gweiStr string) (*big.Int, error) {
 func FormatGwei(wei *big.Int) string {
 	return internal.FormatGwei(wei)
 }
+func NewNonceManager() types.NonceManager {
+	return internal.NewNonceManager()
+}
+func NewFeeHistoryStrategy(params types.EstimateFeesPerGasParams) types.FeeStrategy {
+	return internal.NewFeeHistoryStrategy(params)
+}
+func ToBlobs(data []byte) ([]kzg4844.Blob, error) {
+	return internal.ToBlobs(data)
+}
+func FromBlobs(blobs []kzg4844.Blob) ([]byte, error) {
+	return internal.FromBlobs(blobs)
+}
+func BlobsToSidecar(blobs []kzg4844.Blob) (*ethTypes.BlobTxSidecar, error) {
+	return internal.BlobsToSidecar(blobs)
+}
+func BlobVersionedHashes(sidecar *ethTypes.BlobTxSidecar) []string {
+	return internal.BlobVersionedHashes(sidecar)
+}
+func SignAuthorization(authorization types.Authorization, account *types.Account) (*types.SignedAuthorization, error) {
+	return internal.SignAuthorization(authorization, account.PrivateKey)
+}
+func RecoverAuthorizationAddress(authorization types.SignedAuthorization) (string, error) {
+	return internal.RecoverAuthorizationAddress(authorization)
+}